@@ -0,0 +1,95 @@
+package neo4j
+
+import "strconv"
+
+type Aggregation interface {
+	Property
+	Distinct() Aggregation
+}
+
+type aggregation struct {
+	property
+	function   string
+	argument   Property
+	percentile string
+	distinct   bool
+}
+
+func CountAll() Aggregation {
+	return aggregate("count", nil, "")
+}
+
+func Count(prop Property) Aggregation {
+	return aggregate("count", prop, "")
+}
+
+func Collect(prop Property) Aggregation {
+	return aggregate("collect", prop, "")
+}
+
+func Sum(prop Property) Aggregation {
+	return aggregate("sum", prop, "")
+}
+
+func Avg(prop Property) Aggregation {
+	return aggregate("avg", prop, "")
+}
+
+func Min(prop Property) Aggregation {
+	return aggregate("min", prop, "")
+}
+
+func Max(prop Property) Aggregation {
+	return aggregate("max", prop, "")
+}
+
+func StDev(prop Property) Aggregation {
+	return aggregate("stDev", prop, "")
+}
+
+func PercentileCont(prop Property, percentile float64) Aggregation {
+	return aggregate("percentileCont", prop, strconv.FormatFloat(percentile, 'f', -1, 64))
+}
+
+func PercentileDisc(prop Property, percentile float64) Aggregation {
+	return aggregate("percentileDisc", prop, strconv.FormatFloat(percentile, 'f', -1, 64))
+}
+
+func (a aggregation) Distinct() Aggregation {
+	a.distinct = true
+	a.property = a.build()
+	return a
+}
+
+func (a aggregation) build() property {
+	if a.argument == nil {
+		return property{
+			name:  a.function + "(*)",
+			alias: a.function,
+		}
+	}
+	argument, params := a.argument.Get().eval()
+	alias := a.function + "_" + a.argument.key()
+	if a.distinct {
+		argument = "DISTINCT " + argument
+		alias = a.function + "_distinct_" + a.argument.key()
+	}
+	if a.percentile != "" {
+		argument += ", " + a.percentile
+	}
+	return property{
+		name:   a.function + "(" + argument + ")",
+		alias:  alias,
+		params: params,
+	}
+}
+
+func aggregate(function string, prop Property, percentile string) Aggregation {
+	a := aggregation{
+		function:   function,
+		argument:   prop,
+		percentile: percentile,
+	}
+	a.property = a.build()
+	return a
+}
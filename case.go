@@ -0,0 +1,94 @@
+package neo4j
+
+import "fmt"
+
+type CaseExpression interface {
+	Property
+	When(Operation, interface{}) CaseExpression
+	Else(interface{}) Property
+}
+
+type SimpleCaseExpression interface {
+	Property
+	When(interface{}, interface{}) SimpleCaseExpression
+	Else(interface{}) Property
+}
+
+type caseExpression struct {
+	property
+	subject    interface{}
+	conditions []interface{}
+	values     []interface{}
+	otherwise  interface{}
+}
+
+type genericCase struct {
+	caseExpression
+}
+
+type simpleCase struct {
+	caseExpression
+}
+
+func Case(condition Operation, value interface{}) CaseExpression {
+	return genericCase{caseExpression{}.when(condition, value)}
+}
+
+func CaseOf(subject interface{}, match interface{}, value interface{}) SimpleCaseExpression {
+	return simpleCase{caseExpression{subject: subject}.when(match, value)}
+}
+
+func (c genericCase) When(condition Operation, value interface{}) CaseExpression {
+	return genericCase{c.when(condition, value)}
+}
+
+func (c simpleCase) When(match interface{}, value interface{}) SimpleCaseExpression {
+	return simpleCase{c.when(match, value)}
+}
+
+func (c caseExpression) Else(value interface{}) Property {
+	c.otherwise = value
+	return c.build()
+}
+
+func (c caseExpression) when(condition interface{}, value interface{}) caseExpression {
+	c.conditions = append(c.conditions[:len(c.conditions):len(c.conditions)], condition)
+	c.values = append(c.values[:len(c.values):len(c.values)], value)
+	return c.build()
+}
+
+func (c caseExpression) build() caseExpression {
+	name := "CASE"
+	params := Records{}
+	add := func(keyword string, value interface{}, alias string) {
+		operand, p := argument(value, alias)
+		operand, params = combine(params, operand, p)
+		name += " " + keyword + operand
+	}
+	if c.subject != nil {
+		add("", c.subject, "case_subject")
+	}
+	for i := range c.conditions {
+		if c.subject == nil {
+			condition, _ := c.conditions[i].(Operation)
+			if condition == nil {
+				condition = operation{value: "true"}
+			}
+			operand, p := condition.eval()
+			operand, params = combine(params, operand, p)
+			name += " WHEN " + operand
+		} else {
+			add("WHEN ", c.conditions[i], fmt.Sprintf("case_when_%d", i))
+		}
+		add("THEN ", c.values[i], fmt.Sprintf("case_then_%d", i))
+	}
+	if c.otherwise != nil {
+		add("ELSE ", c.otherwise, "case_else")
+	}
+	c.property = property{
+		name:   name + " END",
+		alias:  "case",
+		params: params,
+	}
+	return c
+}
@@ -38,18 +38,43 @@ func (c *Client) Write(transaction Transaction) ([]Records, error) {
 	return c.run(transaction, neo4j.AccessModeWrite)
 }
 
-func (c *Client) run(transaction Transaction, accessMode neo4j.AccessMode) (result []Records, err error) {
+func (c *Client) AutoCommit(query Query) (records []Records, err error) {
+	driver, session, err := c.connect(neo4j.AccessModeWrite)
+	if err != nil {
+		return
+	}
+	defer driver.Close()
+	defer session.Close()
+	operation, params := query.eval()
+	log.Printf("[Query] %s", operation)
+	log.Printf("[Params] %s", params)
+	result, err := session.Run(operation, params)
+	if err != nil {
+		return
+	}
+	return collect(result)
+}
+
+func (c *Client) connect(accessMode neo4j.AccessMode) (driver neo4j.Driver, session neo4j.Session, err error) {
 	auth := neo4j.BasicAuth(c.Username, c.Password, "")
 	addr := fmt.Sprintf("bolt://%s:%d", c.Host, c.Port)
-	driver, err := neo4j.NewDriver(addr, auth)
+	driver, err = neo4j.NewDriver(addr, auth)
 	if err != nil {
 		return
 	}
-	defer driver.Close()
-	session, err := driver.Session(accessMode)
+	session, err = driver.Session(accessMode)
+	if err != nil {
+		driver.Close()
+	}
+	return
+}
+
+func (c *Client) run(transaction Transaction, accessMode neo4j.AccessMode) (result []Records, err error) {
+	driver, session, err := c.connect(accessMode)
 	if err != nil {
 		return
 	}
+	defer driver.Close()
 	defer session.Close()
 	workTransaction := func(tx neo4j.Transaction) (interface{}, error) {
 		return transaction(&job{transaction: tx})
@@ -78,6 +103,10 @@ func (j *job) Execute(query Query) (records []Records, err error) {
 	if err != nil {
 		return
 	}
+	return collect(result)
+}
+
+func collect(result neo4j.Result) (records []Records, err error) {
 	for result.Next() {
 		err = result.Err()
 		if err != nil {
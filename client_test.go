@@ -1,10 +1,13 @@
 package neo4j_test
 
 import (
+	"encoding/base64"
 	"fmt"
+	driver "github.com/neo4j/neo4j-go-driver/neo4j"
 	"github.com/phiskills/neo4j-client.go"
 	"strings"
 	"testing"
+	"time"
 )
 
 type example struct {
@@ -16,6 +19,35 @@ func (e example) String() string {
 	return fmt.Sprintf("[Query] %s\n[Params] %v\n", e.operation, e.params)
 }
 
+type node struct {
+	id     int64
+	labels []string
+	props  map[string]interface{}
+}
+
+func (n node) Id() int64                     { return n.id }
+func (n node) Labels() []string              { return n.labels }
+func (n node) Props() map[string]interface{} { return n.props }
+
+type relationship struct {
+	id, start, end int64
+	kind           string
+}
+
+func (r relationship) Id() int64                     { return r.id }
+func (r relationship) StartId() int64                { return r.start }
+func (r relationship) EndId() int64                  { return r.end }
+func (r relationship) Type() string                  { return r.kind }
+func (r relationship) Props() map[string]interface{} { return map[string]interface{}{} }
+
+type path struct {
+	nodes         []driver.Node
+	relationships []driver.Relationship
+}
+
+func (p path) Nodes() []driver.Node                 { return p.nodes }
+func (p path) Relationships() []driver.Relationship { return p.relationships }
+
 const errorFormat = "## Invalid Query:\n- received:\n%v\n- expected:\n%v"
 
 var client = &neo4j.Client{}
@@ -49,6 +81,29 @@ func TestQuery_Create_Node(t *testing.T) {
 	validate(t, query, example)
 }
 
+func TestQuery_Merge(t *testing.T) {
+	queries := []string{
+		"MERGE (user:User{name: $user_name}) MERGE (product:Product{id: $product_id})",
+		"MERGE (user)-[:OWNS]->(product)",
+		"RETURN user.name, product.id",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_name": "John", "product_id": 42},
+	}
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}, Props: neo4j.Records{"name": "John"}}
+	product := &neo4j.Node{Id: "product", Labels: []string{"Product"}, Props: neo4j.Records{"id": 42}}
+	owns := &neo4j.Path{
+		Origin:       &neo4j.Node{Id: "user"},
+		Relationship: &neo4j.Relationship{Type: "OWNS", Direction: neo4j.FromOriginToDestination},
+		Destination:  &neo4j.Node{Id: "product"},
+	}
+	query := client.NewRequest()
+	query = query.Merge(user, product).Merge(owns)
+	query = query.Return(user.Property("name"), product.Property("id"))
+	validate(t, query, example)
+}
+
 func TestQuery_Create_Relationship(t *testing.T) {
 	queries := []string{
 		"MATCH (user:User{id: $user_id})",
@@ -362,9 +417,9 @@ func TestQuery_Merge_OnCreate_OnMatch(t *testing.T) {
 func TestQuery_Where_Conditions(t *testing.T) {
 	queries := []string{
 		"MATCH (user)",
-		"WHERE NOT user.banned AND user.name = $user_name XOR (user.age < $user_age AND user.grade >= $user_grade)",
-		"OR (NOT (user.city STARTS WITH $user_city XOR user.country ENDS WITH $user_country))",
-		"AND (NOT (NOT user.description CONTAINS $user_description OR user.email =~ $user_email))",
+		"WHERE (NOT user.banned AND user.name = $user_name XOR user.age < $user_age AND user.grade >= $user_grade",
+		"OR NOT (user.city STARTS WITH $user_city XOR user.country ENDS WITH $user_country))",
+		"AND NOT (NOT user.description CONTAINS $user_description OR user.email =~ $user_email)",
 		"AND (user.uuid IS NOT NULL XOR user.admin IS NULL)",
 		"RETURN user.name",
 	}
@@ -425,6 +480,1049 @@ func TestQuery_With_OrderBy_Skip_Limit(t *testing.T) {
 	validate(t, query, example)
 }
 
+func TestQuery_Return_Aggregations(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User)-[owns:OWNS]->(product:Product)",
+		"RETURN user.country, count(*), count(DISTINCT product.id), collect(product.id), avg(user.age), max(owns.price), percentileCont(owns.price, 0.9)",
+		"ORDER BY count(*) DESC",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{},
+	}
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	product := &neo4j.Node{Id: "product", Labels: []string{"Product"}}
+	owns := &neo4j.Relationship{
+		Id:        "owns",
+		Type:      "OWNS",
+		Direction: neo4j.FromOriginToDestination,
+	}
+	path := &neo4j.Path{
+		Origin:       user,
+		Relationship: owns,
+		Destination:  product,
+	}
+	query := client.NewRequest()
+	query = query.Match(path)
+	query = query.Return(
+		user.Property("country"),
+		neo4j.CountAll(),
+		neo4j.Count(product.Property("id")).Distinct(),
+		neo4j.Collect(product.Property("id")),
+		neo4j.Avg(user.Property("age")),
+		neo4j.Max(owns.Property("price")),
+		neo4j.PercentileCont(owns.Property("price"), 0.9),
+	)
+	query = query.OrderBy(neo4j.CountAll()).Desc()
+	validate(t, query, example)
+}
+
+func TestPagination_Apply(t *testing.T) {
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	active := user.Property("active").IsEqual(true)
+	pagination := &neo4j.Pagination{
+		OrderBy:    user.Property("age"),
+		Tiebreaker: user.Property("id"),
+		Limit:      2,
+	}
+	query, err := pagination.Apply(client.NewRequest().Match(user), "", active, user.Property("name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate(t, query, example{
+		operation: "MATCH (user:User) WHERE user.active = $user_active RETURN user.name, user.age, user.id ORDER BY user.age, user.id LIMIT 2",
+		params:    neo4j.Records{"user_active": true},
+	})
+	cursor, err := pagination.Next([]neo4j.Records{
+		{"user.name": "John", "user.age": 20, "user.id": "000"},
+		{"user.name": "Jane", "user.age": 30, "user.id": "111"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err = pagination.Apply(client.NewRequest().Match(user), cursor, active, user.Property("name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := []string{
+		"MATCH (user:User)",
+		"WHERE user.active = $user_active",
+		"AND (user.age > $user_age_cursor OR user.age = $user_age_cursor AND user.id > $user_id_cursor)",
+		"RETURN user.name, user.age, user.id",
+		"ORDER BY user.age, user.id",
+		"LIMIT 2",
+	}
+	validate(t, query, example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_active": true, "user_age_cursor": int64(30), "user_id_cursor": "111"},
+	})
+	query, err = pagination.Apply(client.NewRequest().Match(user), cursor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate(t, query, example{
+		operation: strings.Join([]string{
+			"MATCH (user:User)",
+			"WHERE user.age > $user_age_cursor OR user.age = $user_age_cursor AND user.id > $user_id_cursor",
+			"RETURN user.age, user.id",
+			"ORDER BY user.age, user.id",
+			"LIMIT 2",
+		}, " "),
+		params: neo4j.Records{"user_age_cursor": int64(30), "user_id_cursor": "111"},
+	})
+	if _, err = pagination.Apply(client.NewRequest().Match(user), "???", nil); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+	if _, err = pagination.Next([]neo4j.Records{
+		{"user.age": 20, "user.id": "000"},
+		{"user.age": []int{30}, "user.id": "111"},
+	}); err == nil {
+		t.Error("expected an error for an unsupported cursor value")
+	}
+	for _, forged := range []string{`[{"a": 1}, "111"]`, `[[30], "111"]`, `[null, "111"]`} {
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(forged))
+		if _, err = pagination.Apply(client.NewRequest().Match(user), cursor, nil); err == nil {
+			t.Errorf("expected an error for the forged cursor %s", forged)
+		}
+	}
+}
+
+func TestPagination_Temporal(t *testing.T) {
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	pagination := &neo4j.Pagination{
+		OrderBy:    user.Property("createdAt"),
+		Tiebreaker: user.Property("birthday"),
+		Limit:      1,
+	}
+	createdAt := time.Date(2020, 5, 17, 10, 30, 0, 0, time.UTC)
+	birthday := driver.DateOf(time.Date(1990, 1, 31, 0, 0, 0, 0, time.UTC))
+	cursor, err := pagination.Next([]neo4j.Records{{"user.createdAt": createdAt, "user.birthday": birthday}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := pagination.Apply(client.NewRequest().Match(user), cursor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := []string{
+		"MATCH (user:User)",
+		"WHERE user.createdAt > $user_createdAt_cursor",
+		"OR user.createdAt = $user_createdAt_cursor AND user.birthday > $user_birthday_cursor",
+		"RETURN user.createdAt, user.birthday",
+		"ORDER BY user.createdAt, user.birthday",
+		"LIMIT 1",
+	}
+	validate(t, query, example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_createdAt_cursor": createdAt, "user_birthday_cursor": birthday},
+	})
+}
+
+func TestQuery_With_Aliases_Where(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User)-[:PLACED]->(order:Order)",
+		"WITH user, count(order) AS orders, user.name AS name",
+		"WHERE orders > $orders",
+		"WITH DISTINCT *",
+		"RETURN name, orders",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"orders": 3},
+	}
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	order := &neo4j.Node{Id: "order", Labels: []string{"Order"}}
+	path := &neo4j.Path{
+		Origin:       user,
+		Relationship: &neo4j.Relationship{Type: "PLACED", Direction: neo4j.FromOriginToDestination},
+		Destination:  order,
+	}
+	orders := neo4j.NewAlias("orders")
+	query := client.NewRequest()
+	query = query.Match(path)
+	query = query.With(
+		"user",
+		neo4j.Count(order.Variable()).As("orders"),
+		user.Property("name").As("name"),
+	)
+	query = query.Where(orders.GreaterThan(3))
+	query = query.WithDistinct(neo4j.Wildcard())
+	query = query.Return(neo4j.NewAlias("name"), orders)
+	validate(t, query, example)
+}
+
+func TestQuery_DetachDelete_Path(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{id: $user_id})-[owns:OWNS]->(product:Product)",
+		"DETACH DELETE user, owns, product",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_id": "000"},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	path := &neo4j.Path{
+		Origin:       user,
+		Relationship: &neo4j.Relationship{Id: "owns", Type: "OWNS", Direction: neo4j.FromOriginToDestination},
+		Destination:  &neo4j.Node{Id: "product", Labels: []string{"Product"}},
+	}
+	query := client.NewRequest()
+	query = query.Match(path)
+	query = query.DetachDelete(user, path, "product")
+	validate(t, query, example)
+	ids := []string{"user", "owns", "product"}
+	query = client.NewRequest().Match(path).DetachDelete(ids)
+	validate(t, query, example)
+}
+
+func TestQuery_Remove_Labels(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User:`Pending Review`{id: $user_id})",
+		"REMOVE user.email, user.`first name`",
+		"SET user:Verified:`Trusted Buyer`",
+		"REMOVE user:`Pending Review`",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_id": "000"},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User", "`Pending Review`"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Remove(user, "email", "first name")
+	query = query.AddLabels(user, "Verified", "Trusted Buyer")
+	query = query.RemoveLabels(user, "Pending Review")
+	validate(t, query, example)
+}
+
+func TestQuery_Set_Properties_Map(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{id: $user_id})",
+		"MATCH (backup:Backup)",
+		"SET user += $user_props",
+		"SET backup = properties(user)",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"user_id":    "000",
+			"user_props": map[string]interface{}{"name": "John", "age": 21},
+		},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	backup := &neo4j.Node{Id: "backup", Labels: []string{"Backup"}}
+	query := client.NewRequest()
+	query = query.Match(user).Match(backup)
+	query = query.MergeProperties(user, neo4j.Records{"name": "John", "age": 21})
+	query = query.CopyProperties(backup, user)
+	validate(t, query, example)
+}
+
+func TestQuery_Replace_Properties(t *testing.T) {
+	example := example{
+		operation: "MATCH (user{id: $user_id}) SET user = $user_props",
+		params: neo4j.Records{
+			"user_id":    "000",
+			"user_props": map[string]interface{}{"id": "000", "name": "John"},
+		},
+	}
+	user := &neo4j.Node{Id: "user", Props: neo4j.Records{"id": "000"}}
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.ReplaceProperties(user, neo4j.Records{"id": "000", "name": "John"})
+	validate(t, query, example)
+}
+
+func TestQuery_Call_SubQuery(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{id: $user_id})",
+		"CALL { WITH user MATCH (user)-[:PLACED]->(order:Order{status: $order_status}) RETURN count(order) AS orders }",
+		"RETURN user.id, orders",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_id": "000", "order_status": "paid"},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	order := &neo4j.Node{
+		Id:     "order",
+		Labels: []string{"Order"},
+		Props:  neo4j.Records{"status": "paid"},
+	}
+	path := &neo4j.Path{
+		Origin:       &neo4j.Node{Id: "user"},
+		Relationship: &neo4j.Relationship{Type: "PLACED", Direction: neo4j.FromOriginToDestination},
+		Destination:  order,
+	}
+	subQuery := client.NewRequest()
+	subQuery = subQuery.Match(path).Return(neo4j.Count(order.Variable()).As("orders"))
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Call(subQuery, user.Variable())
+	query = query.Return(user.Property("id"), neo4j.NewAlias("orders"))
+	validate(t, query, example)
+}
+
+func TestQuery_Call_InTransactions(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{inactive: $user_inactive})",
+		"CALL { WITH user DETACH DELETE user } IN TRANSACTIONS OF 1000 ROWS ON ERROR CONTINUE",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_inactive": true},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"inactive": true},
+	}
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.CallInTransactions(client.NewRequest().DetachDelete(user), 1000, neo4j.ContinueOnError, user.Variable())
+	validate(t, query, example)
+}
+
+func TestQuery_Procedure_Yield(t *testing.T) {
+	queries := []string{
+		"CALL db.index.fulltext.queryNodes($db_index_fulltext_queryNodes_0, $db_index_fulltext_queryNodes_1)",
+		"YIELD node AS user, score",
+		"WHERE score > $score",
+		"RETURN user.name, score",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"db_index_fulltext_queryNodes_0": "users",
+			"db_index_fulltext_queryNodes_1": "John",
+			"score":                          0.5,
+		},
+	}
+	user := neo4j.NewAlias("user")
+	score := neo4j.NewAlias("score")
+	query := client.NewRequest()
+	query = query.Procedure("db.index.fulltext.queryNodes", "users", "John")
+	query = query.Yield(neo4j.NewAlias("node").As("user"), score)
+	query = query.Where(score.GreaterThan(0.5))
+	query = query.Return(user.Property("name"), score)
+	validate(t, query, example)
+}
+
+func TestQuery_Union(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{country: $user_country}) RETURN user.name",
+		"UNION",
+		"MATCH (user:User{country: $user_country_2}) RETURN user.name",
+		"UNION",
+		"MATCH (user:Admin{country: $user_country}) RETURN user.name",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_country": "FR", "user_country_2": "US"},
+	}
+	var branches []neo4j.Query
+	for _, kind := range []struct{ label, country string }{{"User", "FR"}, {"User", "US"}, {"Admin", "FR"}} {
+		user := &neo4j.Node{
+			Id:     "user",
+			Labels: []string{kind.label},
+			Props:  neo4j.Records{"country": kind.country},
+		}
+		branches = append(branches, client.NewRequest().Match(user).Return(user.Property("name")))
+	}
+	query, err := neo4j.Union(branches...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate(t, query, example)
+	user := &neo4j.Node{Id: "user"}
+	invalid := client.NewRequest().Match(user).Return(user.Property("email"))
+	if _, err = neo4j.UnionAll(branches[0], invalid); err == nil {
+		t.Error("expected an error for mismatching columns")
+	}
+	custom := client.NewRequest().Custom("RETURN 1 AS x", nil)
+	if _, err = neo4j.Union(custom, client.NewRequest().Custom("RETURN 2 AS y", nil)); err == nil {
+		t.Error("expected an error for unknown columns")
+	}
+	if _, err = neo4j.Union(branches[0], client.NewRequest().Match(user)); err == nil {
+		t.Error("expected an error for a query without RETURN")
+	}
+}
+
+func TestQuery_Foreach(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{id: $user_id})",
+		"FOREACH (tag IN $tag_list | MERGE (t:Tag{name: tag}) MERGE (user)-[:TAGGED{since: $_since}]->(t))",
+		"FOREACH (product IN products | MERGE (user)-[:OWNS]->(product))",
+		"FOREACH (_ IN CASE WHEN user.score > $user_score THEN [1] ELSE [] END | SET user.verified = $user_verified)",
+		"SET user.active = $user_active",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"user_id": "000", "tag_list": []string{"new", "vip"}, "_since": 2020,
+			"user_score": 10, "user_verified": true, "user_active": true,
+		},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	tag := &neo4j.Node{Id: "t", Labels: []string{"Tag"}, Props: neo4j.Records{"name": neo4j.NewAlias("tag")}}
+	tagged := &neo4j.Path{
+		Origin:       &neo4j.Node{Id: "user"},
+		Relationship: &neo4j.Relationship{Type: "TAGGED", Props: neo4j.Records{"since": 2020}, Direction: neo4j.FromOriginToDestination},
+		Destination:  &neo4j.Node{Id: "t"},
+	}
+	owns := &neo4j.Path{
+		Origin:       &neo4j.Node{Id: "user"},
+		Relationship: &neo4j.Relationship{Type: "OWNS", Direction: neo4j.FromOriginToDestination},
+		Destination:  &neo4j.Node{Id: "product"},
+	}
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Foreach("tag", []string{"new", "vip"}, func(q neo4j.Query) neo4j.Query {
+		return q.Merge(tag).Merge(tagged)
+	})
+	query = query.Foreach("product", neo4j.NewAlias("products"), func(q neo4j.Query) neo4j.Query {
+		return q.Merge(owns)
+	})
+	query = query.When(user.Property("score").GreaterThan(10), func(q neo4j.Query) neo4j.Query {
+		return q.Set(user, neo4j.Records{"verified": true})
+	})
+	query = query.When(neo4j.AllOf(), func(q neo4j.Query) neo4j.Query {
+		return q.Set(user, neo4j.Records{"active": true})
+	})
+	validate(t, query, example)
+}
+
+func TestQuery_Match_VariableLength(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{id: $user_id})-[:KNOWS*1..3]->(friend)",
+		"MATCH (user)-[*]-(other)",
+		"MATCH (user)<-[parents:CHILD_OF*..5{verified: $parents_verified}]-(parent)",
+		"MATCH (user)-[:KNOWS*2]->(contact)",
+		"MATCH (user)-[:KNOWS*2..]->(stranger)",
+		"MATCH (user)-[:MANAGES*0..3]->(team)",
+		"MATCH (user)-[:MANAGES*0]->(self)",
+		"RETURN friend.name",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_id": "000", "parents_verified": true},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	friend := &neo4j.Node{Id: "friend"}
+	hops := []struct {
+		relationship *neo4j.Relationship
+		destination  *neo4j.Node
+	}{
+		{&neo4j.Relationship{Type: "KNOWS", Direction: neo4j.FromOriginToDestination, Hops: neo4j.HopRange(1, 3)}, friend},
+		{&neo4j.Relationship{Hops: neo4j.AnyHops()}, &neo4j.Node{Id: "other"}},
+		{&neo4j.Relationship{
+			Id:        "parents",
+			Type:      "CHILD_OF",
+			Props:     neo4j.Records{"verified": true},
+			Direction: neo4j.FromDestinationToOrigin,
+			Hops:      neo4j.MaxHops(5),
+		}, &neo4j.Node{Id: "parent"}},
+		{&neo4j.Relationship{Type: "KNOWS", Direction: neo4j.FromOriginToDestination, Hops: neo4j.ExactHops(2)}, &neo4j.Node{Id: "contact"}},
+		{&neo4j.Relationship{Type: "KNOWS", Direction: neo4j.FromOriginToDestination, Hops: neo4j.MinHops(2)}, &neo4j.Node{Id: "stranger"}},
+		{&neo4j.Relationship{Type: "MANAGES", Direction: neo4j.FromOriginToDestination, Hops: neo4j.HopRange(0, 3)}, &neo4j.Node{Id: "team"}},
+		{&neo4j.Relationship{Type: "MANAGES", Direction: neo4j.FromOriginToDestination, Hops: neo4j.ExactHops(0)}, &neo4j.Node{Id: "self"}},
+	}
+	query := client.NewRequest()
+	for i, hop := range hops {
+		origin := &neo4j.Node{Id: "user"}
+		if i == 0 {
+			origin = user
+		}
+		query = query.Match(&neo4j.Path{Origin: origin, Relationship: hop.relationship, Destination: hop.destination})
+	}
+	query = query.Return(friend.Property("name"))
+	validate(t, query, example)
+}
+
+func TestQuery_Match_LabelExpressions(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User|Admin)-[rel:OWNS|RENTS]->(product:Product&!Deleted)",
+		"MATCH (item:Product&(Book|Movie)&!`Out Of Stock`)",
+		"MATCH (other:!(User|Admin))",
+		"MATCH (other)-[:LIKES|FOLLOWS|BLOCKS]-(user)",
+		"RETURN user.id, product.id",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{},
+	}
+	user := &neo4j.Node{Id: "user", Kind: neo4j.AnyLabel("User", "Admin")}
+	product := &neo4j.Node{Id: "product", Labels: []string{"Product"}, Kind: neo4j.Label("Deleted").Not()}
+	rel := &neo4j.Relationship{
+		Id:        "rel",
+		Kind:      neo4j.Label("OWNS").Or(neo4j.Label("RENTS")),
+		Direction: neo4j.FromOriginToDestination,
+	}
+	item := &neo4j.Node{
+		Id:     "item",
+		Labels: []string{"Product"},
+		Kind:   neo4j.AnyLabel("Book", "Movie").And(neo4j.Label("Out Of Stock").Not()),
+	}
+	other := &neo4j.Node{Id: "other", Kind: neo4j.AnyLabel("User", "Admin").Not()}
+	query := client.NewRequest()
+	query = query.Match(&neo4j.Path{Origin: user, Relationship: rel, Destination: product})
+	query = query.Match(item)
+	query = query.Match(other)
+	query = query.Match(&neo4j.Path{
+		Origin:       &neo4j.Node{Id: "other"},
+		Relationship: &neo4j.Relationship{Type: "LIKES", Kind: neo4j.AnyLabel("FOLLOWS", "BLOCKS")},
+		Destination:  &neo4j.Node{Id: "user"},
+	})
+	query = query.Return(user.Property("id"), product.Property("id"))
+	validate(t, query, example)
+}
+
+func TestQuery_Match_ShortestPath(t *testing.T) {
+	queries := []string{
+		"MATCH (a:City{name: $a_name})",
+		"MATCH (b:City{name: $b_name})",
+		"MATCH p = shortestPath((a)-[:ROAD*..10]-(b))",
+		"MATCH allShortestPaths((a)-[:RAIL*]->(b))",
+		"RETURN p",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"a_name": "Paris", "b_name": "Lyon"},
+	}
+	a := &neo4j.Node{Id: "a", Labels: []string{"City"}, Props: neo4j.Records{"name": "Paris"}}
+	b := &neo4j.Node{Id: "b", Labels: []string{"City"}, Props: neo4j.Records{"name": "Lyon"}}
+	shortest := neo4j.ShortestPath("p", &neo4j.Path{
+		Origin:       &neo4j.Node{Id: "a"},
+		Relationship: &neo4j.Relationship{Type: "ROAD", Hops: neo4j.MaxHops(10)},
+		Destination:  &neo4j.Node{Id: "b"},
+	})
+	all := neo4j.AllShortestPaths("", &neo4j.Path{
+		Origin:       &neo4j.Node{Id: "a"},
+		Relationship: &neo4j.Relationship{Type: "RAIL", Hops: neo4j.AnyHops(), Direction: neo4j.FromOriginToDestination},
+		Destination:  &neo4j.Node{Id: "b"},
+	})
+	query := client.NewRequest()
+	query = query.Match(a).Match(b)
+	query = query.Match(shortest)
+	query = query.Match(all)
+	query = query.Return(shortest.Variable())
+	validate(t, query, example)
+}
+
+func TestDecodePath(t *testing.T) {
+	paris := node{id: 1, labels: []string{"City"}, props: map[string]interface{}{"name": "Paris"}}
+	dijon := node{id: 2, labels: []string{"City"}, props: map[string]interface{}{"name": "Dijon"}}
+	lyon := node{id: 3, labels: []string{"City"}, props: map[string]interface{}{"name": "Lyon"}}
+	raw := path{
+		nodes: []driver.Node{paris, dijon, lyon},
+		relationships: []driver.Relationship{
+			relationship{id: 10, start: 1, end: 2, kind: "ROAD"},
+			relationship{id: 11, start: 3, end: 2, kind: "ROAD"},
+		},
+	}
+	decoded, err := neo4j.DecodePath(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	example := example{
+		operation: "MATCH (:City{name: $_name})-[:ROAD]->(:City{name: $_name_3})<-[:ROAD]-(:City{name: $_name_2})",
+		params:    neo4j.Records{"_name": "Paris", "_name_2": "Lyon", "_name_3": "Dijon"},
+	}
+	validate(t, client.NewRequest().Match(decoded), example)
+	if _, err = neo4j.DecodePath("p"); err == nil {
+		t.Error("expected an error for an invalid path")
+	}
+}
+
+func TestQuery_Match_NamedPath(t *testing.T) {
+	queries := []string{
+		"MATCH p = (user:User{id: $user_id})-[*]->(product:Product)",
+		"WHERE length(p) <= $length_p",
+		"RETURN nodes(p), relationships(p), length(p)",
+		"ORDER BY length(p)",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_id": "000", "length_p": 3},
+	}
+	path := &neo4j.Path{
+		Id:           "p",
+		Origin:       &neo4j.Node{Id: "user", Labels: []string{"User"}, Props: neo4j.Records{"id": "000"}},
+		Relationship: &neo4j.Relationship{Hops: neo4j.AnyHops(), Direction: neo4j.FromOriginToDestination},
+		Destination:  &neo4j.Node{Id: "product", Labels: []string{"Product"}},
+	}
+	length := neo4j.Length(path.Variable())
+	query := client.NewRequest()
+	query = query.Match(path)
+	query = query.Where(length.LessEqual(3))
+	query = query.Return(neo4j.Nodes(path.Variable()), neo4j.Relationships(path.Variable()), length)
+	query = query.OrderBy(length)
+	validate(t, query, example)
+}
+
+func TestQuery_Match_MultiplePatterns(t *testing.T) {
+	queries := []string{
+		"MATCH (a:User{id: $a_id}), (b:User{id: $b_id})",
+		"CREATE (a)-[:KNOWS{since: $_since}]->(b), (b)-[:KNOWS{since: $_since_2}]->(a)",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"a_id": "000", "b_id": "111", "_since": 2019, "_since_2": 2020},
+	}
+	a := &neo4j.Node{Id: "a", Labels: []string{"User"}, Props: neo4j.Records{"id": "000"}}
+	b := &neo4j.Node{Id: "b", Labels: []string{"User"}, Props: neo4j.Records{"id": "111"}}
+	knows := func(origin, destination string, since int) *neo4j.Path {
+		return &neo4j.Path{
+			Origin: &neo4j.Node{Id: origin},
+			Relationship: &neo4j.Relationship{
+				Type:      "KNOWS",
+				Props:     neo4j.Records{"since": since},
+				Direction: neo4j.FromOriginToDestination,
+			},
+			Destination: &neo4j.Node{Id: destination},
+		}
+	}
+	query := client.NewRequest()
+	query = query.Match(a, b)
+	query = query.Create(knows("a", "b", 2019), knows("b", "a", 2020))
+	validate(t, query, example)
+}
+
+func TestQuery_Create_FluentPath(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{id: $user_id})",
+		"MATCH (product:Product{id: $product_id})",
+		"CREATE ()<--(user)-[owns:OWNS{id: $owns_id}]->()--(product)",
+		"RETURN user.id, product.id, owns.id",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"user_id": "000", "product_id": "111", "owns_id": "222"},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	product := &neo4j.Node{
+		Id:     "product",
+		Labels: []string{"Product"},
+		Props:  neo4j.Records{"id": "111"},
+	}
+	owns := &neo4j.Relationship{
+		Id:    "owns",
+		Type:  "OWNS",
+		Props: neo4j.Records{"id": "222"},
+	}
+	path := neo4j.From(nil).
+		In(nil).To(&neo4j.Node{Id: "user"}).
+		Out(owns).To(nil).
+		Both(nil).To(&neo4j.Node{Id: "product"})
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Match(product)
+	query = query.Create(path)
+	query = query.Return(
+		user.Property("id"),
+		product.Property("id"),
+		owns.Property("id"),
+	)
+	validate(t, query, example)
+}
+
+func TestQuery_Match_ReversedPath(t *testing.T) {
+	queries := []string{
+		"MATCH (store:Store)-[sold:SOLD]->(product:Product)<-[owns:OWNS]-(user:User)",
+		"MATCH (user)-[owns]->(product)<-[sold]-(store)",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{},
+	}
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	product := &neo4j.Node{Id: "product", Labels: []string{"Product"}}
+	store := &neo4j.Node{Id: "store", Labels: []string{"Store"}}
+	owns := &neo4j.Relationship{Id: "owns", Type: "OWNS", Direction: neo4j.FromOriginToDestination}
+	sold := &neo4j.Relationship{Id: "sold", Type: "SOLD", Direction: neo4j.FromDestinationToOrigin}
+	path := &neo4j.Path{
+		Origin:       &neo4j.Path{Origin: user, Relationship: owns, Destination: product},
+		Relationship: sold,
+		Destination:  store,
+	}
+	query := client.NewRequest()
+	query = query.Match(path.Reverse())
+	query = query.Match(neo4j.From(&neo4j.Node{Id: "user"}).
+		Out(&neo4j.Relationship{Id: "owns"}).To(&neo4j.Node{Id: "product"}).
+		In(&neo4j.Relationship{Id: "sold"}).To(&neo4j.Node{Id: "store"}))
+	validate(t, query, example)
+}
+
+func TestQuery_Match_FluentPath_Reuse(t *testing.T) {
+	queries := []string{
+		"MATCH (user)-[:OWNS]->(product)<-[:SOLD]-(store)",
+		"MATCH (user)-[:OWNS]->(product)-[:IN]-(category)",
+		"MATCH (user)-[:OWNS]->(product)",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{},
+	}
+	base := neo4j.From(&neo4j.Node{Id: "user"}).Out(&neo4j.Relationship{Type: "OWNS"}).To(&neo4j.Node{Id: "product"})
+	query := client.NewRequest()
+	query = query.Match(base.In(&neo4j.Relationship{Type: "SOLD"}).To(&neo4j.Node{Id: "store"}))
+	query = query.Match(base.Both(&neo4j.Relationship{Type: "IN"}).To(&neo4j.Node{Id: "category"}))
+	query = query.Match(base)
+	validate(t, query, example)
+	expectPanic(t, "To without a relationship", func() { neo4j.From(nil).To(nil) })
+	expectPanic(t, "two relationships in a row", func() { base.Out(nil).In(nil) })
+	expectPanic(t, "a dangling relationship", func() { client.NewRequest().Match(base.Out(nil)) })
+}
+
+func TestQuery_Where_Exists_Count(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User)",
+		"WHERE EXISTS { (user)-[:OWNS]->(:Product{category: $_category}) }",
+		"AND NOT EXISTS { MATCH (user)-[:BANNED_BY]->(admin:Admin) WHERE admin.active = $admin_active }",
+		"AND COUNT { (user)-[:PLACED]->() } > $count",
+		"RETURN user.name, COUNT { (user)-[:PLACED]->() } AS orders",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"_category": "books", "admin_active": true, "count": 3},
+	}
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	admin := &neo4j.Node{Id: "admin", Labels: []string{"Admin"}}
+	owns := neo4j.From(&neo4j.Node{Id: "user"}).
+		Out(&neo4j.Relationship{Type: "OWNS"}).
+		To(&neo4j.Node{Labels: []string{"Product"}, Props: neo4j.Records{"category": "books"}})
+	banned := client.NewRequest().
+		Match(neo4j.From(&neo4j.Node{Id: "user"}).Out(&neo4j.Relationship{Type: "BANNED_BY"}).To(admin)).
+		Where(admin.Property("active").IsEqual(true))
+	orders := neo4j.CountOf(neo4j.From(&neo4j.Node{Id: "user"}).Out(&neo4j.Relationship{Type: "PLACED"}).To(nil))
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Where(
+		neo4j.Exists(owns).And(neo4j.Not(neo4j.Exists(banned))).And(orders.GreaterThan(3)),
+	)
+	query = query.Return(user.Property("name"), orders.As("orders"))
+	validate(t, query, example)
+}
+
+func TestQuery_Where_PropertyComparisons(t *testing.T) {
+	queries := []string{
+		"MATCH (buyer:User)-[:WANTS]->(product:Product)",
+		"WHERE product.price <= buyer.budget",
+		"AND product.updated > product.created",
+		"AND product.name STARTS WITH buyer.prefix",
+		"AND buyer.country IN product.countries",
+		"AND product.stock > $product_stock",
+		"AND product.flagged = (product.price IS NULL OR buyer.budget IS NULL)",
+		"AND product.premium = (product.price > $product_price)",
+		"RETURN product.name",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params:    neo4j.Records{"product_stock": 0, "product_price": 100},
+	}
+	buyer := &neo4j.Node{Id: "buyer", Labels: []string{"User"}}
+	product := &neo4j.Node{Id: "product", Labels: []string{"Product"}}
+	query := client.NewRequest()
+	query = query.Match(neo4j.From(buyer).Out(&neo4j.Relationship{Type: "WANTS"}).To(product))
+	query = query.Where(
+		product.Property("price").LessEqual(buyer.Property("budget")).And(
+			product.Property("updated").GreaterThan(product.Property("created")),
+		).And(
+			product.Property("name").StartsWith(buyer.Property("prefix")),
+		).And(
+			buyer.Property("country").In(product.Property("countries")),
+		).And(
+			product.Property("stock").GreaterThan(0),
+		).And(
+			product.Property("flagged").IsEqual(product.Property("price").IsNull().Or(buyer.Property("budget").IsNull())),
+		).And(
+			product.Property("premium").IsEqual(product.Property("price").GreaterThan(100)),
+		),
+	)
+	query = query.Return(product.Property("name"))
+	validate(t, query, example)
+}
+
+func TestQuery_Inline(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{age: 20, name: 'John \\'Jo\\' Doe'})",
+		"WHERE user.score >= 1.5 AND user.tags IN [['a', 'b'], null]",
+		"SET user.address = {city: 'Paris', `zip code`: '75001'}, user.avatar = $user_avatar, user.birthday = date('2000-01-31'), user.manager = null, user.profile = $user_profile",
+		"RETURN user.name",
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"name": "John 'Jo' Doe", "age": 20},
+	}
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Where(
+		user.Property("score").GreaterEqual(1.5).And(
+			user.Property("tags").In([]interface{}{[]string{"a", "b"}, nil}),
+		),
+	)
+	query = query.Set(user, neo4j.Records{
+		"address":  map[string]string{"city": "Paris", "zip code": "75001"},
+		"birthday": driver.DateOf(time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC)),
+		"manager":  nil,
+		"profile":  struct{ Bio string }{"unknown"},
+		"avatar":   []byte("hi"),
+	})
+	query = query.Return(user.Property("name"))
+	received := query.Inline()
+	expected := strings.Join(queries, " ")
+	if received != expected {
+		t.Errorf(errorFormat, received, expected)
+	}
+}
+
+func TestQuery_Expressions(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User)",
+		"WHERE toLower(trim(user.name)) STARTS WITH $toLower_trim_user_name",
+		"AND size(split(user.tags, $split_user_tags_1)) > $size_split_user_tags",
+		"SET user.score = (user.score + $user_score_add) * user.level ^ $user_level_power,",
+		"user.slug = substring(user.name, $substring_user_name_1, $substring_user_name_2)",
+		"RETURN coalesce(user.nickname, user.name, $coalesce_user_nickname_user_name_2),",
+		"toInteger(user.age) % $toInteger_user_age_modulo, head(reverse(range($range_0, $range_1)))",
+		"ORDER BY user.score - (user.penalty - user.bonus)",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"toLower_trim_user_name": "jo", "split_user_tags_1": ",", "size_split_user_tags": 2,
+			"user_score_add": 10, "user_level_power": 2,
+			"substring_user_name_1": 0, "substring_user_name_2": 3,
+			"coalesce_user_nickname_user_name_2": "anonymous", "toInteger_user_age_modulo": 10,
+			"range_0": 1, "range_1": 10,
+		},
+	}
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Where(
+		neo4j.ToLower(neo4j.Trim(user.Property("name"))).StartsWith("jo").And(
+			neo4j.Size(neo4j.Split(user.Property("tags"), ",")).GreaterThan(2),
+		),
+	)
+	query = query.Set(user, neo4j.Records{
+		"score": user.Property("score").Add(10).Multiply(user.Property("level").Power(2)),
+		"slug":  neo4j.Substring(user.Property("name"), 0, 3),
+	})
+	query = query.Return(
+		neo4j.Coalesce(user.Property("nickname"), user.Property("name"), "anonymous"),
+		neo4j.ToInteger(user.Property("age")).Modulo(10),
+		neo4j.Head(neo4j.Reverse(neo4j.Range(1, 10))),
+	)
+	query = query.OrderBy(user.Property("score").Subtract(user.Property("penalty").Subtract(user.Property("bonus"))))
+	validate(t, query, example)
+}
+
+func TestQuery_Case(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User)",
+		"WITH user, CASE WHEN user.age < $user_age THEN $case_then_0 WHEN user.age < $user_age_2 THEN $case_then_1 ELSE $case_else END AS bucket",
+		"SET user.tier = CASE user.country WHEN $case_when_0 THEN user.score * $user_score_multiply WHEN $case_when_1 THEN user.score + $user_score_add ELSE user.score END",
+		"RETURN bucket, count(*)",
+		"ORDER BY CASE bucket WHEN $case_when_0_3 THEN $case_then_0_2 ELSE $case_else_2 END,",
+		"CASE bucket WHEN $case_when_0_2 THEN $case_then_0_2 ELSE $case_else_2 END",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"user_age": 18, "user_age_2": 65,
+			"case_then_0": "minor", "case_then_1": "adult", "case_else": "senior",
+			"case_when_0": "FR", "user_score_multiply": 2, "case_when_1": "DE", "user_score_add": 1,
+			"case_when_0_3": "minor", "case_then_0_2": 0, "case_else_2": 1, "case_when_0_2": "adult",
+		},
+	}
+	user := &neo4j.Node{Id: "user", Labels: []string{"User"}}
+	bucket := neo4j.NewAlias("bucket")
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.With(
+		user.Variable(),
+		neo4j.Case(user.Property("age").LessThan(18), "minor").
+			When(user.Property("age").LessThan(65), "adult").
+			Else("senior").As("bucket"),
+	)
+	query = query.Set(user, neo4j.Records{
+		"tier": neo4j.CaseOf(user.Property("country"), "FR", user.Property("score").Multiply(2)).
+			When("DE", user.Property("score").Add(1)).
+			Else(user.Property("score")),
+	})
+	query = query.Return(bucket, neo4j.CountAll())
+	query = query.OrderBy(neo4j.CaseOf(bucket, "minor", 0).Else(1), neo4j.CaseOf(bucket, "adult", 0).Else(1))
+	validate(t, query, example)
+}
+
+func TestQuery_Projections_Comprehensions(t *testing.T) {
+	queries := []string{
+		"MATCH (user:User{id: $user_id})",
+		"RETURN user {.name, .email, orders: [(user)-[:PLACED]->(o:Order) WHERE o.total > $o_total | o {.id, .total}],",
+		"scores: [score IN user.scores WHERE score > $score | score * $score_multiply]} AS user,",
+		"[tag IN $tag_list | toUpper(tag)] AS tags",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"user_id": "000", "o_total": 100, "score": 10, "score_multiply": 2,
+			"tag_list": []string{"new", "vip"},
+		},
+	}
+	user := &neo4j.Node{
+		Id:     "user",
+		Labels: []string{"User"},
+		Props:  neo4j.Records{"id": "000"},
+	}
+	order := &neo4j.Node{Id: "o", Labels: []string{"Order"}}
+	placed := neo4j.From(&neo4j.Node{Id: "user"}).Out(&neo4j.Relationship{Type: "PLACED"}).To(order)
+	score := neo4j.NewAlias("score")
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Return(
+		neo4j.Project(user, "name", "email").
+			With("orders", neo4j.PatternComprehension(
+				placed,
+				order.Property("total").GreaterThan(100),
+				neo4j.Project(order, "id", "total"),
+			)).
+			With("scores", neo4j.Comprehension(
+				"score",
+				user.Property("scores"),
+				score.GreaterThan(10),
+				score.Multiply(2),
+			)).As("user"),
+		neo4j.Comprehension("tag", []string{"new", "vip"}, nil, neo4j.ToUpper(neo4j.NewAlias("tag"))).As("tags"),
+	)
+	validate(t, query, example)
+}
+
+func TestQuery_Where_Quantifiers(t *testing.T) {
+	queries := []string{
+		"MATCH p = (user:User)-[*]->(product:Product)",
+		"WHERE all(n IN nodes(p) WHERE n.active = $n_active)",
+		"AND (any(tag IN user.tags WHERE tag IN $tag) OR none(score IN $score_list WHERE score > user.score))",
+		"AND NOT single(r IN relationships(p) WHERE r.blocked IS NOT NULL)",
+		"AND any(role IN user.roles WHERE true)",
+		"RETURN product.id",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"n_active": true, "tag": []interface{}{"vip", "new"}, "score_list": []int{10, 20},
+		},
+	}
+	path := &neo4j.Path{
+		Id:           "p",
+		Origin:       &neo4j.Node{Id: "user", Labels: []string{"User"}},
+		Relationship: &neo4j.Relationship{Hops: neo4j.AnyHops(), Direction: neo4j.FromOriginToDestination},
+		Destination:  &neo4j.Node{Id: "product", Labels: []string{"Product"}},
+	}
+	user := &neo4j.Node{Id: "user"}
+	n, tag, score, r := neo4j.NewAlias("n"), neo4j.NewAlias("tag"), neo4j.NewAlias("score"), neo4j.NewAlias("r")
+	query := client.NewRequest()
+	query = query.Match(path)
+	query = query.Where(
+		neo4j.All("n", neo4j.Nodes(path.Variable()), n.Property("active").IsEqual(true)).And(
+			neo4j.Any("tag", user.Property("tags"), tag.In([]interface{}{"vip", "new"})).Or(
+				neo4j.None("score", []int{10, 20}, score.GreaterThan(user.Property("score"))),
+			),
+		).And(
+			neo4j.Not(neo4j.Single("r", neo4j.Relationships(path.Variable()), r.Property("blocked").IsNotNull())),
+		).And(
+			neo4j.Any("role", user.Property("roles"), neo4j.AnyOf()),
+		),
+	)
+	query = query.Return(neo4j.NewAlias("product").Property("id"))
+	validate(t, query, example)
+}
+
+func TestQuery_Where_Grouping(t *testing.T) {
+	queries := []string{
+		"MATCH (user)",
+		"WHERE (user.admin = $user_admin OR user.owner = $user_owner) AND user.active = $user_active",
+		"AND (user.age >= $user_age OR user.country IN $user_country AND NOT (user.banned = $user_banned OR user.locked = $user_locked))",
+		"RETURN user.name",
+	}
+	example := example{
+		operation: strings.Join(queries, " "),
+		params: neo4j.Records{
+			"user_admin": true, "user_owner": true, "user_active": true, "user_age": 18,
+			"user_country": []interface{}{"FR", "US"}, "user_banned": true, "user_locked": true,
+		},
+	}
+	user := &neo4j.Node{Id: "user"}
+	query := client.NewRequest()
+	query = query.Match(user)
+	query = query.Where(neo4j.Not(neo4j.AnyOf()))
+	query = query.Where(
+		user.Property("admin").IsEqual(true).Or(user.Property("owner").IsEqual(true)).And(
+			user.Property("active").IsEqual(true),
+		).And(
+			neo4j.AnyOf(
+				user.Property("age").GreaterEqual(18),
+				neo4j.AllOf(
+					user.Property("country").In([]interface{}{"FR", "US"}),
+					neo4j.Not(neo4j.AnyOf(user.Property("banned").IsEqual(true), user.Property("locked").IsEqual(true))),
+				),
+			),
+		),
+	)
+	query = query.Return(user.Property("name"))
+	validate(t, query, example)
+}
+
+func TestQuery_Hops_Validation(t *testing.T) {
+	expectPanic(t, "a negative bound", func() { neo4j.MinHops(-1) })
+	expectPanic(t, "a minimum above the maximum", func() { neo4j.HopRange(3, 1) })
+	path := &neo4j.Path{
+		Origin:       &neo4j.Node{Id: "a"},
+		Relationship: &neo4j.Relationship{Type: "KNOWS", Hops: neo4j.HopRange(1, 3)},
+		Destination:  &neo4j.Node{Id: "b"},
+	}
+	expectPanic(t, "a variable-length CREATE", func() { client.NewRequest().Create(path) })
+	expectPanic(t, "a variable-length MERGE", func() { client.NewRequest().Merge(neo4j.From(path).Out(nil).To(nil)) })
+}
+
+func expectPanic(t *testing.T, name string, f func()) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for %s", name)
+		}
+	}()
+	f()
+}
+
 func validate(t *testing.T, query neo4j.Query, example example) {
 	received := fmt.Sprintf("%s", query)
 	fmt.Printf("# Test:\n%s\n", received)
@@ -0,0 +1,89 @@
+package neo4j
+
+import "strings"
+
+type MapProjection interface {
+	Property
+	With(string, interface{}) MapProjection
+	AllProperties() MapProjection
+}
+
+type mapProjection struct {
+	property
+	variable Property
+	keys     []string
+	values   []interface{}
+	all      bool
+}
+
+func Project(data Data, keys ...string) MapProjection {
+	projection := mapProjection{variable: data.Variable()}
+	for _, key := range keys {
+		projection.keys = append(projection.keys, key)
+		projection.values = append(projection.values, nil)
+	}
+	return projection.build()
+}
+
+func Comprehension(variable string, list interface{}, condition Operation, projection interface{}) Property {
+	operation, params := argument(list, variable+"_list")
+	operation = variable + " IN " + operation
+	return comprehension(operation, params, variable, condition, projection)
+}
+
+func PatternComprehension(pattern structure, condition Operation, projection interface{}) Property {
+	operation, params := pattern.eval()
+	return comprehension(operation, params, "pattern", condition, projection)
+}
+
+func (m mapProjection) With(key string, value interface{}) MapProjection {
+	m.keys = append(m.keys[:len(m.keys):len(m.keys)], key)
+	m.values = append(m.values[:len(m.values):len(m.values)], value)
+	return m.build()
+}
+
+func (m mapProjection) AllProperties() MapProjection {
+	m.all = true
+	return m.build()
+}
+
+func (m mapProjection) build() mapProjection {
+	variable, params := m.variable.Get().eval()
+	var entries []string
+	if m.all {
+		entries = append(entries, ".*")
+	}
+	for i, key := range m.keys {
+		if m.values[i] == nil {
+			entries = append(entries, "."+escape(key))
+			continue
+		}
+		value, p := argument(m.values[i], m.variable.key()+"_"+key)
+		value, params = combine(params, value, p)
+		entries = append(entries, escape(key)+": "+value)
+	}
+	m.property = property{
+		name:   variable + " {" + strings.Join(entries, ", ") + "}",
+		alias:  m.variable.key(),
+		params: params,
+	}
+	return m
+}
+
+func comprehension(operation string, params Records, alias string, condition Operation, projection interface{}) Property {
+	if condition != nil {
+		where, p := condition.eval()
+		where, params = combine(params, where, p)
+		operation += " WHERE " + where
+	}
+	if projection != nil {
+		value, p := argument(projection, alias+"_projection")
+		value, params = combine(params, value, p)
+		operation += " | " + value
+	}
+	return property{
+		name:   "[" + operation + "]",
+		alias:  alias,
+		params: params,
+	}
+}
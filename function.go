@@ -0,0 +1,138 @@
+package neo4j
+
+import (
+	"fmt"
+	"strings"
+)
+
+func Nodes(path Property) Property {
+	return function("nodes", path)
+}
+
+func Relationships(path Property) Property {
+	return function("relationships", path)
+}
+
+func Length(path Property) Property {
+	return function("length", path)
+}
+
+func ToLower(value interface{}) Property {
+	return function("toLower", value)
+}
+
+func ToUpper(value interface{}) Property {
+	return function("toUpper", value)
+}
+
+func Trim(value interface{}) Property {
+	return function("trim", value)
+}
+
+func LTrim(value interface{}) Property {
+	return function("lTrim", value)
+}
+
+func RTrim(value interface{}) Property {
+	return function("rTrim", value)
+}
+
+func Replace(value, search, replacement interface{}) Property {
+	return function("replace", value, search, replacement)
+}
+
+func Substring(value, start, length interface{}) Property {
+	if length == nil {
+		return function("substring", value, start)
+	}
+	return function("substring", value, start, length)
+}
+
+func Left(value, length interface{}) Property {
+	return function("left", value, length)
+}
+
+func Right(value, length interface{}) Property {
+	return function("right", value, length)
+}
+
+func Split(value, delimiter interface{}) Property {
+	return function("split", value, delimiter)
+}
+
+func Size(value interface{}) Property {
+	return function("size", value)
+}
+
+func Range(start, end interface{}, step ...interface{}) Property {
+	return function("range", append([]interface{}{start, end}, step...)...)
+}
+
+func Head(list interface{}) Property {
+	return function("head", list)
+}
+
+func Last(list interface{}) Property {
+	return function("last", list)
+}
+
+func Tail(list interface{}) Property {
+	return function("tail", list)
+}
+
+func Reverse(value interface{}) Property {
+	return function("reverse", value)
+}
+
+func ToInteger(value interface{}) Property {
+	return function("toInteger", value)
+}
+
+func ToFloat(value interface{}) Property {
+	return function("toFloat", value)
+}
+
+func ToString(value interface{}) Property {
+	return function("toString", value)
+}
+
+func ToBoolean(value interface{}) Property {
+	return function("toBoolean", value)
+}
+
+func Coalesce(values ...interface{}) Property {
+	return function("coalesce", values...)
+}
+
+func Abs(value interface{}) Property {
+	return function("abs", value)
+}
+
+func Round(value interface{}) Property {
+	return function("round", value)
+}
+
+func function(name string, args ...interface{}) Property {
+	var aliases []string
+	for _, arg := range args {
+		if prop, ok := arg.(Property); ok {
+			aliases = append(aliases, prop.key())
+		}
+	}
+	alias := name
+	if len(aliases) > 0 {
+		alias += "_" + strings.Join(aliases, "_")
+	}
+	var arguments []string
+	params := Records{}
+	for i, arg := range args {
+		argument, p := argument(arg, fmt.Sprintf("%s_%d", alias, i))
+		argument, params = combine(params, argument, p)
+		arguments = append(arguments, argument)
+	}
+	return property{
+		name:   name + "(" + strings.Join(arguments, ", ") + ")",
+		alias:  alias,
+		params: params,
+	}
+}
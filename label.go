@@ -0,0 +1,99 @@
+package neo4j
+
+import "strings"
+
+const (
+	labelOrPrecedence = iota + 1
+	labelAndPrecedence
+	labelNotPrecedence
+	labelAtomPrecedence
+)
+
+type LabelExpression interface {
+	And(LabelExpression) LabelExpression
+	Or(LabelExpression) LabelExpression
+	Not() LabelExpression
+	eval(int) string
+}
+
+type label struct {
+	name     string
+	operator string
+	operands []LabelExpression
+}
+
+func Label(name string) LabelExpression {
+	return label{name: name}
+}
+
+func AllLabels(names ...string) LabelExpression {
+	return labelList("&", names)
+}
+
+func AnyLabel(names ...string) LabelExpression {
+	return labelList("|", names)
+}
+
+func (l label) And(expression LabelExpression) LabelExpression {
+	return l.combine("&", expression)
+}
+
+func (l label) Or(expression LabelExpression) LabelExpression {
+	return l.combine("|", expression)
+}
+
+func (l label) Not() LabelExpression {
+	return label{operator: "!", operands: []LabelExpression{l}}
+}
+
+func (l label) eval(parent int) string {
+	switch l.operator {
+	case "":
+		return escape(l.name)
+	case "!":
+		return "!" + l.operands[0].eval(labelNotPrecedence)
+	}
+	var operands []string
+	for _, operand := range l.operands {
+		operands = append(operands, operand.eval(l.precedence()))
+	}
+	expression := strings.Join(operands, l.operator)
+	if l.precedence() < parent {
+		expression = "(" + expression + ")"
+	}
+	return expression
+}
+
+func (l label) precedence() int {
+	switch l.operator {
+	case "|":
+		return labelOrPrecedence
+	case "&":
+		return labelAndPrecedence
+	case "!":
+		return labelNotPrecedence
+	default:
+		return labelAtomPrecedence
+	}
+}
+
+func (l label) combine(operator string, expression LabelExpression) LabelExpression {
+	if expression == nil {
+		return l
+	}
+	return label{operator: operator, operands: []LabelExpression{l, expression}}
+}
+
+func labelList(operator string, names []string) LabelExpression {
+	if len(names) == 0 {
+		return nil
+	}
+	if len(names) == 1 {
+		return Label(names[0])
+	}
+	var operands []LabelExpression
+	for _, name := range names {
+		operands = append(operands, Label(name))
+	}
+	return label{operator: operator, operands: operands}
+}
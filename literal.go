@@ -0,0 +1,140 @@
+package neo4j
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/neo4j"
+)
+
+func inline(operation string, params Records) string {
+	return parameter.ReplaceAllStringFunc(operation, func(name string) string {
+		value, ok := params[name[1:]]
+		if !ok {
+			return name
+		}
+		if value, ok := literal(value); ok {
+			return value
+		}
+		return name
+	})
+}
+
+func literal(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "null", true
+	case string:
+		return quote(v), true
+	case time.Time:
+		return "datetime(" + quote(v.Format(time.RFC3339Nano)) + ")", true
+	case neo4j.Date:
+		return "date(" + quote(v.String()) + ")", true
+	case neo4j.LocalTime:
+		return "localtime(" + quote(v.String()) + ")", true
+	case neo4j.OffsetTime:
+		return "time(" + quote(v.String()) + ")", true
+	case neo4j.LocalDateTime:
+		return "localdatetime(" + quote(v.String()) + ")", true
+	case neo4j.Duration:
+		return "duration(" + quote(v.String()) + ")", true
+	case []byte:
+		return "", false
+	case *neo4j.Point:
+		if v == nil {
+			return "null", true
+		}
+		if math.IsNaN(v.Z()) {
+			return fmt.Sprintf("point({srid: %d, x: %s, y: %s})", v.SrId(), float(v.X()), float(v.Y())), true
+		}
+		return fmt.Sprintf("point({srid: %d, x: %s, y: %s, z: %s})", v.SrId(), float(v.X()), float(v.Y()), float(v.Z())), true
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(reflected.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflected.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflected.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return float(reflected.Float()), true
+	case reflect.String:
+		return quote(reflected.String()), true
+	case reflect.Ptr, reflect.Interface:
+		if reflected.IsNil() {
+			return "null", true
+		}
+		return literal(reflected.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if reflected.Kind() == reflect.Slice && reflected.IsNil() {
+			return "null", true
+		}
+		var items []string
+		for i := 0; i < reflected.Len(); i++ {
+			item, ok := literal(reflected.Index(i).Interface())
+			if !ok {
+				return "", false
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", true
+	case reflect.Map:
+		if reflected.Type().Key().Kind() != reflect.String {
+			return "", false
+		}
+		if reflected.IsNil() {
+			return "null", true
+		}
+		var keys []string
+		values := map[string]interface{}{}
+		for _, key := range reflected.MapKeys() {
+			keys = append(keys, key.String())
+			values[key.String()] = reflected.MapIndex(key).Interface()
+		}
+		sort.Strings(keys)
+		var entries []string
+		for _, key := range keys {
+			entry, ok := literal(values[key])
+			if !ok {
+				return "", false
+			}
+			entries = append(entries, escape(key)+": "+entry)
+		}
+		return "{" + strings.Join(entries, ", ") + "}", true
+	default:
+		return "", false
+	}
+}
+
+func quote(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`'`, `\'`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+	return "'" + replacer.Replace(value) + "'"
+}
+
+func float(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	}
+	formatted := strconv.FormatFloat(value, 'f', -1, 64)
+	if !strings.Contains(formatted, ".") {
+		formatted += ".0"
+	}
+	return formatted
+}
@@ -5,6 +5,7 @@ import "strings"
 type Node struct {
 	Id     string
 	Labels []string
+	Kind   LabelExpression
 	Props  Records
 }
 
@@ -23,21 +24,42 @@ func (n *Node) Properties(names ...string) []Property {
 	return props
 }
 
+func (n *Node) Variable() Property {
+	return property{
+		name:  n.Id,
+		alias: n.Id,
+	}
+}
+
+func (n *Node) identifiers() []string {
+	if n == nil || n.Id == "" {
+		return nil
+	}
+	return []string{n.Id}
+}
+
 func (n *Node) eval() (string, Records) {
 	if n == nil {
 		return "()", Records{}
 	}
 	kind := ""
-	for _, label := range n.Labels {
-		kind += ":" + label
+	if n.Kind != nil {
+		expression := n.Kind
+		if len(n.Labels) > 0 {
+			expression = AllLabels(n.Labels...).And(n.Kind)
+		}
+		kind = ":" + expression.eval(0)
+	} else {
+		for _, label := range n.Labels {
+			kind += ":" + label
+		}
 	}
 	var props []string
 	params := Records{}
 	for _, key := range n.Props.Keys() {
-		alias := n.Id + "_" + key
-		prop := key + ": $" + alias
-		props = append(props, prop)
-		params[alias] = n.Props[key]
+		value, p := argument(n.Props[key], n.Id+"_"+key)
+		value, params = combine(params, value, p)
+		props = append(props, key+": "+value)
 	}
 	content := ""
 	if len(props) > 0 {
@@ -50,3 +72,7 @@ func (n *Node) eval() (string, Records) {
 func (n *Node) extends() (string, Records) {
 	return n.eval()
 }
+
+func (n *Node) reverse() Pattern {
+	return n
+}
@@ -2,67 +2,156 @@ package neo4j
 
 import "strings"
 
+const (
+	operationListPrecedence = iota + 1
+	operationOrPrecedence
+	operationXorPrecedence
+	operationAndPrecedence
+	operationNotPrecedence
+	operationComparisonPrecedence
+	operationValuePrecedence
+)
+
 type Operation interface {
 	Then(Operation) Operation
 	And(Operation) Operation
 	Or(Operation) Operation
 	XOr(Operation) Operation
 	eval() (string, Records)
-	isComposite() bool
+	precedence() int
 	invert() Operation
 }
 
 type operation struct {
 	value      string
 	params     Records
-	operations []Operation
-	operators  []string
+	operator   string
+	operands   []Operation
+	comparison bool
+}
+
+// AllOf and AnyOf return nil when given no conditions, which Not and Where ignore.
+func AllOf(operations ...Operation) Operation {
+	return group("AND", operations)
+}
+
+func AnyOf(operations ...Operation) Operation {
+	return group("OR", operations)
+}
+
+// All, Any, None and Single treat a nil condition as true.
+func All(variable string, list interface{}, condition Operation) Operation {
+	return quantifier("all", variable, list, condition)
+}
+
+func Any(variable string, list interface{}, condition Operation) Operation {
+	return quantifier("any", variable, list, condition)
+}
+
+func None(variable string, list interface{}, condition Operation) Operation {
+	return quantifier("none", variable, list, condition)
+}
+
+func Single(variable string, list interface{}, condition Operation) Operation {
+	return quantifier("single", variable, list, condition)
 }
 
 func (o operation) Then(operation Operation) Operation {
-	return o.next(", ", operation)
+	return o.next(",", operation)
 }
 
 func (o operation) And(condition Operation) Operation {
-	return o.next(" AND ", condition)
+	return o.next("AND", condition)
 }
 
 func (o operation) Or(condition Operation) Operation {
-	return o.next(" OR ", condition)
+	return o.next("OR", condition)
 }
 
 func (o operation) XOr(condition Operation) Operation {
-	return o.next(" XOR ", condition)
+	return o.next("XOR", condition)
 }
 
 func (o operation) eval() (string, Records) {
-	operations := []string{o.value}
-	for i, next := range o.operations {
-		operation, params := next.eval()
-		operations = append(operations, o.operators[i])
-		if next.isComposite() {
+	if o.operator == "" {
+		return o.value, o.params
+	}
+	var operations []string
+	params := Records{}
+	for _, operand := range o.operands {
+		operation, p := operand.eval()
+		if operand.precedence() < o.precedence() {
 			operation = "(" + operation + ")"
 		}
+		operation, params = combine(params, operation, p)
 		operations = append(operations, operation)
-		o.params = o.params.Merge(params)
 	}
-	operation := strings.Join(operations, "")
-	return operation, o.params
+	switch o.operator {
+	case "NOT":
+		return "NOT " + operations[0], params
+	case ",":
+		return strings.Join(operations, ", "), params
+	default:
+		return strings.Join(operations, " "+o.operator+" "), params
+	}
 }
 
-func (o operation) isComposite() bool {
-	return len(o.operations) > 0 && o.value != "NOT"
+func (o operation) precedence() int {
+	switch o.operator {
+	case ",":
+		return operationListPrecedence
+	case "OR":
+		return operationOrPrecedence
+	case "XOR":
+		return operationXorPrecedence
+	case "AND":
+		return operationAndPrecedence
+	case "NOT":
+		return operationNotPrecedence
+	}
+	if o.comparison {
+		return operationComparisonPrecedence
+	}
+	return operationValuePrecedence
 }
 
 func (o operation) invert() Operation {
-	return operation{value: ""}.next("NOT ", o)
+	return operation{operator: "NOT", operands: []Operation{o}}
 }
 
 func (o operation) next(operator string, operation Operation) Operation {
 	if operation == nil {
 		return o
 	}
-	o.operations = append(o.operations, operation)
-	o.operators = append(o.operators, operator)
-	return o
+	return group(operator, []Operation{o, operation})
+}
+
+func group(operator string, operations []Operation) Operation {
+	var operands []Operation
+	for _, operation := range operations {
+		if operation != nil {
+			operands = append(operands, operation)
+		}
+	}
+	switch len(operands) {
+	case 0:
+		return nil
+	case 1:
+		return operands[0]
+	default:
+		return operation{operator: operator, operands: operands}
+	}
+}
+
+func quantifier(function string, variable string, list interface{}, condition Operation) Operation {
+	value, params := argument(list, variable+"_list")
+	if condition == nil {
+		condition = operation{value: "true"}
+	}
+	where, conditionParams := condition.eval()
+	where, params = combine(params, where, conditionParams)
+	return operation{
+		value:  function + "(" + variable + " IN " + value + " WHERE " + where + ")",
+		params: params,
+	}
 }
@@ -0,0 +1,190 @@
+package neo4j
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/neo4j"
+)
+
+type cursorValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type Pagination struct {
+	OrderBy    Property
+	Tiebreaker Property
+	Limit      int
+	Descending bool
+}
+
+func (p *Pagination) Apply(query Query, cursor string, filter Operation, props ...Property) (Query, error) {
+	condition, err := p.Condition(cursor)
+	if err != nil {
+		return nil, err
+	}
+	query = query.Where(AllOf(filter, condition))
+	returns := props
+	for _, prop := range []Property{p.OrderBy, p.Tiebreaker} {
+		if !contains(returns, prop) {
+			returns = append(returns, prop)
+		}
+	}
+	order, _ := p.OrderBy.Get().eval()
+	tiebreaker, _ := p.Tiebreaker.Get().eval()
+	operation := "ORDER BY " + order + ", " + tiebreaker
+	if p.Descending {
+		operation = "ORDER BY " + order + " DESC, " + tiebreaker + " DESC"
+	}
+	query = query.Return(returns...).Custom(operation, Records{})
+	return query.Limit(p.Limit), nil
+}
+
+func (p *Pagination) Condition(cursor string) (Operation, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	values, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	comparator := " > $"
+	if p.Descending {
+		comparator = " < $"
+	}
+	order, _ := p.OrderBy.Get().eval()
+	orderAlias := p.OrderBy.key() + "_cursor"
+	tiebreaker, _ := p.Tiebreaker.Get().eval()
+	tiebreakerAlias := p.Tiebreaker.key() + "_cursor"
+	after := operation{
+		value:  order + comparator + orderAlias,
+		params: Records{orderAlias: values[0]},
+	}
+	same := operation{
+		value:  order + " = $" + orderAlias,
+		params: Records{orderAlias: values[0]},
+	}
+	next := operation{
+		value:  tiebreaker + comparator + tiebreakerAlias,
+		params: Records{tiebreakerAlias: values[1]},
+	}
+	return after.Or(same.And(next)), nil
+}
+
+func (p *Pagination) Next(records []Records) (string, error) {
+	if len(records) == 0 || len(records) < p.Limit {
+		return "", nil
+	}
+	last := records[len(records)-1]
+	order, _ := p.OrderBy.Get().eval()
+	tiebreaker, _ := p.Tiebreaker.Get().eval()
+	var values []interface{}
+	for _, column := range []string{order, tiebreaker} {
+		value, err := encodeCursorValue(last[column])
+		if err != nil {
+			return "", fmt.Errorf("%v for %s", err, column)
+		}
+		values = append(values, value)
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(cursor string) ([]interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %v", cursor, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var values []interface{}
+	if err = decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %v", cursor, err)
+	}
+	if len(values) != 2 {
+		return nil, fmt.Errorf("invalid cursor %q: expected 2 values, got %d", cursor, len(values))
+	}
+	for i, value := range values {
+		if values[i], err = decodeCursorValue(value); err != nil {
+			return nil, fmt.Errorf("invalid cursor %q: %v", cursor, err)
+		}
+	}
+	return values, nil
+}
+
+func encodeCursorValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return cursorValue{Type: "datetime", Value: v.Format(time.RFC3339Nano)}, nil
+	case neo4j.Date:
+		return cursorValue{Type: "date", Value: v.Time().Format(time.RFC3339Nano)}, nil
+	case neo4j.LocalDateTime:
+		return cursorValue{Type: "localdatetime", Value: v.Time().Format(time.RFC3339Nano)}, nil
+	case neo4j.LocalTime:
+		return cursorValue{Type: "localtime", Value: v.Time().Format(time.RFC3339Nano)}, nil
+	case neo4j.OffsetTime:
+		return cursorValue{Type: "time", Value: v.Time().Format(time.RFC3339Nano)}, nil
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported cursor value %v of type %T", value, value)
+	}
+}
+
+func decodeCursorValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string, bool:
+		return v, nil
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return integer, nil
+		}
+		return v.Float64()
+	case map[string]interface{}:
+		kind, _ := v["type"].(string)
+		raw, _ := v["value"].(string)
+		if len(v) != 2 || raw == "" {
+			break
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case "datetime":
+			return parsed, nil
+		case "date":
+			return neo4j.DateOf(parsed), nil
+		case "localdatetime":
+			return neo4j.LocalDateTimeOf(parsed), nil
+		case "localtime":
+			return neo4j.LocalTimeOf(parsed), nil
+		case "time":
+			return neo4j.OffsetTimeOf(parsed), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported value %v", value)
+}
+
+func contains(props []Property, prop Property) bool {
+	name, _ := prop.Get().eval()
+	for _, p := range props {
+		if n, _ := p.Get().eval(); n == name {
+			return true
+		}
+	}
+	return false
+}
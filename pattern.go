@@ -0,0 +1,88 @@
+package neo4j
+
+import "fmt"
+
+// PathBuilder steps return a new builder, so a partial path can be reused.
+// To without a pending relationship, two relationships in a row or a path
+// ending on a relationship panic.
+type PathBuilder struct {
+	origin  Pattern
+	hops    []hop
+	pending *Relationship
+}
+
+type hop struct {
+	relationship *Relationship
+	destination  Pattern
+}
+
+func From(origin Pattern) *PathBuilder {
+	return &PathBuilder{origin: origin}
+}
+
+func (b *PathBuilder) Out(relationship *Relationship) *PathBuilder {
+	return b.through(relationship, FromOriginToDestination)
+}
+
+func (b *PathBuilder) In(relationship *Relationship) *PathBuilder {
+	return b.through(relationship, FromDestinationToOrigin)
+}
+
+func (b *PathBuilder) Both(relationship *Relationship) *PathBuilder {
+	return b.through(relationship, NoDirection)
+}
+
+func (b *PathBuilder) To(destination *Node) *PathBuilder {
+	if b.pending == nil {
+		panic(fmt.Errorf("invalid path: To without a relationship"))
+	}
+	var next Pattern = destination
+	if destination == nil {
+		next = &Node{}
+	}
+	hops := append(b.hops[:len(b.hops):len(b.hops)], hop{relationship: b.pending, destination: next})
+	return &PathBuilder{origin: b.origin, hops: hops}
+}
+
+func (b *PathBuilder) Path() *Path {
+	if b.pending != nil {
+		panic(fmt.Errorf("invalid path: relationship without a destination"))
+	}
+	path := &Path{Origin: b.origin}
+	if len(b.hops) == 0 {
+		return path
+	}
+	last := len(b.hops) - 1
+	var destination Pattern = b.hops[last].destination
+	for i := last; i > 0; i-- {
+		destination = &Path{
+			Origin:       b.hops[i-1].destination,
+			Relationship: b.hops[i].relationship,
+			Destination:  destination,
+		}
+	}
+	path.Relationship = b.hops[0].relationship
+	path.Destination = destination
+	return path
+}
+
+func (b *PathBuilder) eval() (string, Records) {
+	return b.Path().eval()
+}
+
+func (b *PathBuilder) identifiers() []string {
+	return b.Path().identifiers()
+}
+
+func (b *PathBuilder) through(relationship *Relationship, direction Direction) *PathBuilder {
+	if b.pending != nil {
+		panic(fmt.Errorf("invalid path: two relationships without a destination in between"))
+	}
+	hop := &Relationship{}
+	if relationship != nil {
+		copied := *relationship
+		hop = &copied
+	}
+	hop.Direction = direction
+	return &PathBuilder{origin: b.origin, hops: b.hops, pending: hop}
+}
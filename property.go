@@ -3,6 +3,7 @@ package neo4j
 type Data interface {
 	Property(name string) Property
 	Properties(names ...string) []Property
+	Variable() Property
 }
 
 type Property interface {
@@ -13,23 +14,78 @@ type Property interface {
 	LessEqual(interface{}) Operation
 	GreaterThan(interface{}) Operation
 	GreaterEqual(interface{}) Operation
-	StartsWith(string) Operation
-	EndsWith(string) Operation
+	StartsWith(interface{}) Operation
+	EndsWith(interface{}) Operation
 	Contains(interface{}) Operation
-	In([]interface{}) Operation
-	Matches(string) Operation
+	In(interface{}) Operation
+	Matches(interface{}) Operation
 	IsNull() Operation
 	IsNotNull() Operation
+	Add(interface{}) Property
+	Subtract(interface{}) Property
+	Multiply(interface{}) Property
+	Divide(interface{}) Property
+	Modulo(interface{}) Property
+	Power(interface{}) Property
+	As(string) Property
 	invert() Operation
+	key() string
+	precedence() int
+	project() (string, Records)
 }
 
+const (
+	arithmeticAdditivePrecedence = iota + 1
+	arithmeticMultiplicativePrecedence
+	arithmeticPowerPrecedence
+	arithmeticAtomPrecedence
+)
+
 type property struct {
-	name  string
-	alias string
+	name   string
+	alias  string
+	params Records
+	source Property
+	rank   int
+}
+
+type Alias struct {
+	property
+}
+
+func NewAlias(name string) *Alias {
+	return &Alias{property{name: name, alias: name}}
+}
+
+func Wildcard() Property {
+	return property{name: "*"}
+}
+
+func (a *Alias) Property(name string) Property {
+	return property{
+		name:  a.name + "." + name,
+		alias: a.alias + "_" + name,
+	}
+}
+
+func (a *Alias) Properties(names ...string) []Property {
+	var props []Property
+	for _, name := range names {
+		props = append(props, a.Property(name))
+	}
+	return props
+}
+
+func (a *Alias) Variable() Property {
+	return a.property
+}
+
+func (a *Alias) identifiers() []string {
+	return []string{a.name}
 }
 
 func (p property) Get() Operation {
-	return operation{value: p.name}
+	return operation{value: p.name, params: p.params}
 }
 
 func (p property) IsEqual(value interface{}) Operation {
@@ -56,11 +112,11 @@ func (p property) GreaterEqual(value interface{}) Operation {
 	return p.apply(">=", value)
 }
 
-func (p property) StartsWith(value string) Operation {
+func (p property) StartsWith(value interface{}) Operation {
 	return p.apply("STARTS WITH", value)
 }
 
-func (p property) EndsWith(value string) Operation {
+func (p property) EndsWith(value interface{}) Operation {
 	return p.apply("ENDS WITH", value)
 }
 
@@ -68,29 +124,121 @@ func (p property) Contains(value interface{}) Operation {
 	return p.apply("CONTAINS", value)
 }
 
-func (p property) In(values []interface{}) Operation {
+func (p property) In(values interface{}) Operation {
 	return p.apply("IN", values)
 }
 
-func (p property) Matches(regex string) Operation {
+func (p property) Matches(regex interface{}) Operation {
 	return p.apply("=~", regex)
 }
 
 func (p property) IsNull() Operation {
-	return operation{value: p.name + " IS NULL"}
+	return operation{value: p.name + " IS NULL", params: p.params, comparison: true}
 }
 
 func (p property) IsNotNull() Operation {
-	return operation{value: p.name + " IS NOT NULL"}
+	return operation{value: p.name + " IS NOT NULL", params: p.params, comparison: true}
+}
+
+func (p property) Add(value interface{}) Property {
+	return p.compute("+", "add", arithmeticAdditivePrecedence, value)
+}
+
+func (p property) Subtract(value interface{}) Property {
+	return p.compute("-", "subtract", arithmeticAdditivePrecedence, value)
+}
+
+func (p property) Multiply(value interface{}) Property {
+	return p.compute("*", "multiply", arithmeticMultiplicativePrecedence, value)
+}
+
+func (p property) Divide(value interface{}) Property {
+	return p.compute("/", "divide", arithmeticMultiplicativePrecedence, value)
+}
+
+func (p property) Modulo(value interface{}) Property {
+	return p.compute("%", "modulo", arithmeticMultiplicativePrecedence, value)
+}
+
+func (p property) Power(value interface{}) Property {
+	return p.compute("^", "power", arithmeticPowerPrecedence, value)
+}
+
+func (p property) As(alias string) Property {
+	source := Property(p)
+	if p.source != nil {
+		source = p.source
+	}
+	return property{
+		name:   alias,
+		alias:  alias,
+		source: source,
+	}
 }
 
 func (p property) apply(operator string, value interface{}) Operation {
+	operand, params := argument(value, p.alias)
+	operand, params = combine(p.params, operand, params)
 	return operation{
-		value:  p.name + " " + operator + " $" + p.alias,
-		params: Records{p.alias: value},
+		value:      p.name + " " + operator + " " + operand,
+		params:     params,
+		comparison: true,
+	}
+}
+
+func (p property) compute(operator string, name string, precedence int, value interface{}) Property {
+	left := p.name
+	if p.precedence() < precedence {
+		left = "(" + left + ")"
+	}
+	alias := p.alias + "_" + name
+	right, params := argument(value, alias)
+	if prop, ok := value.(Property); ok && prop.precedence() <= precedence {
+		right = "(" + right + ")"
+	}
+	right, params = combine(p.params, right, params)
+	return property{
+		name:   left + " " + operator + " " + right,
+		alias:  alias,
+		params: params,
+		rank:   precedence,
 	}
 }
 
 func (p property) invert() Operation {
-	return operation{value: "NOT " + p.name}
+	return p.Get().invert()
+}
+
+func (p property) key() string {
+	return p.alias
+}
+
+func (p property) precedence() int {
+	if p.rank == 0 {
+		return arithmeticAtomPrecedence
+	}
+	return p.rank
+}
+
+func (p property) project() (string, Records) {
+	if p.source == nil {
+		return p.name, p.params
+	}
+	source, params := p.source.Get().eval()
+	return source + " AS " + p.name, params
+}
+
+func argument(value interface{}, alias string) (string, Records) {
+	switch expression := value.(type) {
+	case Property:
+		return expression.Get().eval()
+	case Operation:
+		operand, params := expression.eval()
+		if expression.precedence() < operationValuePrecedence {
+			operand = "(" + operand + ")"
+		}
+		return operand, params
+	default:
+		return "$" + alias, Records{alias: value}
+	}
 }
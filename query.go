@@ -2,16 +2,37 @@ package neo4j
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 )
 
+type ErrorBehavior int
+
+const (
+	FailOnError ErrorBehavior = iota
+	ContinueOnError
+	BreakOnError
+)
+
 type Query interface {
 	Custom(string, Records) Query
-	Create(structure structure) Query
+	Call(Query, ...Property) Query
+	CallInTransactions(Query, int, ErrorBehavior, ...Property) Query
+	Procedure(string, ...interface{}) Query
+	Yield(...Property) Query
+	Create(...structure) Query
 	Set(Data, Records) Query
-	Delete(...string) Query
-	Match(structure structure) Query
-	Merge(structure structure) Query
+	MergeProperties(Data, Records) Query
+	ReplaceProperties(Data, Records) Query
+	CopyProperties(Data, Data) Query
+	Remove(Data, ...string) Query
+	AddLabels(*Node, ...string) Query
+	RemoveLabels(*Node, ...string) Query
+	Delete(...interface{}) Query
+	DetachDelete(...interface{}) Query
+	Match(...structure) Query
+	Merge(...structure) Query
 	OnCreate() Query
 	OnMatch() Query
 	Optional() Query
@@ -20,43 +41,129 @@ type Query interface {
 	Limit(int) Query
 	Skip(int) Query
 	Where(Operation) Query
-	With(...string) Query
+	Foreach(string, interface{}, func(Query) Query) Query
+	When(Operation, func(Query) Query) Query
+	With(...interface{}) Query
+	WithDistinct(...interface{}) Query
 	Return(...Property) Query
+	ReturnDistinct(...Property) Query
 	eval() (string, Records)
+	columns() []string
+	Inline() string
 	String() string
 }
 
 type query struct {
 	operations []string
 	params     Records
+	returns    []string
 }
 
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+var parameter = regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*`)
+
 type structure interface {
 	eval() (string, Records)
 }
 
+type identifiable interface {
+	identifiers() []string
+}
+
 type invertible interface {
 	invert() Operation
 }
 
 func Not(i invertible) Operation {
+	if i == nil {
+		return nil
+	}
 	return i.invert()
 }
 
+func Exists(structure structure) Operation {
+	value, params := structure.eval()
+	return operation{value: "EXISTS { " + value + " }", params: params}
+}
+
+func CountOf(structure structure) Property {
+	value, params := structure.eval()
+	return property{
+		name:   "COUNT { " + value + " }",
+		alias:  "count",
+		params: params,
+	}
+}
+
 func (q query) String() string {
 	query, params := q.eval()
 	return fmt.Sprintf("[Query] %s\n[Params] %v\n", query, params)
 }
 
+func Union(queries ...Query) (Query, error) {
+	return union(" UNION ", queries)
+}
+
+func UnionAll(queries ...Query) (Query, error) {
+	return union(" UNION ALL ", queries)
+}
+
+// Inline leaves parameters that have no Cypher literal form, such as structs and byte slices, as $name.
+func (q query) Inline() string {
+	return inline(q.eval())
+}
+
 func (q query) Custom(operation string, params Records) Query {
+	operation, params = combine(q.params, operation, params)
 	return query{
-		operations: append(q.operations, operation),
-		params:     q.params.Merge(params),
+		operations: append(q.operations[:len(q.operations):len(q.operations)], operation),
+		params:     params,
+		returns:    q.returns,
 	}
 }
 
-func (q query) Create(structure structure) Query {
-	return q.primary("CREATE", structure)
+func (q query) Call(subQuery Query, imports ...Property) Query {
+	operation, params := q.subQuery(subQuery, imports)
+	return q.Custom("CALL "+operation, params)
+}
+
+func (q query) CallInTransactions(subQuery Query, rows int, onError ErrorBehavior, imports ...Property) Query {
+	operation, params := q.subQuery(subQuery, imports)
+	operation = "CALL " + operation + " IN TRANSACTIONS"
+	if rows > 0 {
+		operation += fmt.Sprintf(" OF %d ROWS", rows)
+	}
+	switch onError {
+	case FailOnError:
+	case ContinueOnError:
+		operation += " ON ERROR CONTINUE"
+	case BreakOnError:
+		operation += " ON ERROR BREAK"
+	default:
+		panic(fmt.Errorf("invalid ErrorBehavior %v", onError))
+	}
+	return q.Custom(operation, params)
+}
+
+func (q query) Procedure(name string, args ...interface{}) Query {
+	var arguments []string
+	params := Records{}
+	prefix := strings.ReplaceAll(name, ".", "_")
+	for i, arg := range args {
+		value, param := argument(arg, fmt.Sprintf("%s_%d", prefix, i))
+		arguments = append(arguments, value)
+		params = params.Merge(param)
+	}
+	operation := "CALL " + name + "(" + strings.Join(arguments, ", ") + ")"
+	return q.Custom(operation, params)
+}
+
+func (q query) Yield(props ...Property) Query {
+	return q.projection("YIELD", props)
+}
+
+func (q query) Create(structures ...structure) Query {
+	return q.primary("CREATE", structures)
 }
 
 func (q query) Set(data Data, props Records) Query {
@@ -72,17 +179,65 @@ func (q query) Set(data Data, props Records) Query {
 	return q.Custom("SET "+operation, params)
 }
 
-func (q query) Delete(ids ...string) Query {
-	operation := strings.Join(ids, ", ")
-	return q.Custom("DELETE "+operation, Records{})
+func (q query) MergeProperties(data Data, props Records) Query {
+	if data == nil || len(props) == 0 {
+		return q
+	}
+	return q.properties("+=", data, props)
+}
+
+func (q query) ReplaceProperties(data Data, props Records) Query {
+	if data == nil {
+		return q
+	}
+	return q.properties("=", data, props)
+}
+
+func (q query) CopyProperties(to Data, from Data) Query {
+	if to == nil || from == nil {
+		return q
+	}
+	target, _ := to.Variable().Get().eval()
+	source, _ := from.Variable().Get().eval()
+	return q.Custom("SET "+target+" = properties("+source+")", Records{})
 }
 
-func (q query) Match(structure structure) Query {
-	return q.primary("MATCH", structure)
+func (q query) Remove(data Data, keys ...string) Query {
+	if data == nil || len(keys) == 0 {
+		return q
+	}
+	variable, _ := data.Variable().Get().eval()
+	var props []string
+	for _, key := range keys {
+		props = append(props, variable+"."+escape(key))
+	}
+	operation := strings.Join(props, ", ")
+	return q.Custom("REMOVE "+operation, Records{})
 }
 
-func (q query) Merge(structure structure) Query {
-	return q.primary("MERGE", structure)
+func (q query) AddLabels(node *Node, labels ...string) Query {
+	return q.labels("SET", node, labels)
+}
+
+func (q query) RemoveLabels(node *Node, labels ...string) Query {
+	return q.labels("REMOVE", node, labels)
+}
+
+// Delete and DetachDelete accept ids, id slices passed without spreading, and identifiable values.
+func (q query) Delete(items ...interface{}) Query {
+	return q.delete("DELETE", items)
+}
+
+func (q query) DetachDelete(items ...interface{}) Query {
+	return q.delete("DETACH DELETE", items)
+}
+
+func (q query) Match(structures ...structure) Query {
+	return q.primary("MATCH", structures)
+}
+
+func (q query) Merge(structures ...structure) Query {
+	return q.primary("MERGE", structures)
 }
 
 func (q query) OnCreate() Query {
@@ -105,8 +260,8 @@ func (q query) OrderBy(props ...Property) Query {
 	for _, prop := range props {
 		operations = append(operations, prop.Get())
 	}
-	operation, _ := chain(operations).eval()
-	return q.Custom("ORDER BY "+operation, Records{})
+	operation, params := chain(operations).eval()
+	return q.Custom("ORDER BY "+operation, params)
 }
 
 func (q query) Desc() Query {
@@ -124,36 +279,247 @@ func (q query) Skip(limit int) Query {
 }
 
 func (q query) Where(condition Operation) Query {
+	if condition == nil {
+		return q
+	}
 	operation, params := condition.eval()
 	return q.Custom("WHERE "+operation, params)
 }
 
-func (q query) With(ids ...string) Query {
-	operation := strings.Join(ids, ", ")
-	return q.Custom("WITH "+operation, Records{})
+func (q query) Foreach(alias string, list interface{}, updates func(Query) Query) Query {
+	value, params := argument(list, alias+"_list")
+	return q.foreach(alias, value, params, updates)
+}
+
+// When applies the updates unconditionally when the condition is nil.
+func (q query) When(condition Operation, updates func(Query) Query) Query {
+	if condition == nil {
+		return updates(q)
+	}
+	operation, params := condition.eval()
+	value := "CASE WHEN " + operation + " THEN [1] ELSE [] END"
+	return q.foreach("_", value, params, updates)
+}
+
+func (q query) With(items ...interface{}) Query {
+	return q.projection("WITH", projections("WITH", items))
+}
+
+func (q query) WithDistinct(items ...interface{}) Query {
+	return q.projection("WITH DISTINCT", projections("WITH DISTINCT", items))
 }
 
 func (q query) Return(props ...Property) Query {
+	return q.projection("RETURN", props)
+}
+
+func (q query) ReturnDistinct(props ...Property) Query {
+	return q.projection("RETURN DISTINCT", props)
+}
+
+func (q query) eval() (string, Records) {
+	operation := strings.Join(q.operations, " ")
+	return operation, q.params
+}
+
+func (q query) columns() []string {
+	return q.returns
+}
+
+func (q query) primary(instruction string, structures []structure) Query {
+	if len(structures) == 0 {
+		return q
+	}
+	// CREATE and MERGE cannot take variable-length relationships, so building one panics.
+	if instruction != "MATCH" {
+		for _, structure := range structures {
+			if variableLength(structure) {
+				panic(fmt.Errorf("invalid %s with a variable-length relationship", instruction))
+			}
+		}
+	}
+	var patterns []string
+	params := Records{}
+	for _, structure := range structures {
+		pattern, p := structure.eval()
+		pattern, params = combine(params, pattern, p)
+		patterns = append(patterns, pattern)
+	}
+	separator := ", "
+	if instruction == "MERGE" {
+		separator = " MERGE "
+	}
+	operation := instruction + " " + strings.Join(patterns, separator)
+	return q.Custom(operation, params)
+}
+
+func (q query) subQuery(subQuery Query, imports []Property) (string, Records) {
+	if len(imports) > 0 {
+		subQuery = query{}.projection("WITH", imports).Custom(subQuery.eval())
+	}
+	operation, params := subQuery.eval()
+	return "{ " + operation + " }", params
+}
+
+func (q query) properties(operator string, data Data, props Records) Query {
+	variable := data.Variable()
+	target, _ := variable.Get().eval()
+	alias := variable.key() + "_props"
+	if props == nil {
+		props = Records{}
+	}
+	operation := "SET " + target + " " + operator + " $" + alias
+	return q.Custom(operation, Records{alias: map[string]interface{}(props)})
+}
+
+func (q query) labels(instruction string, node *Node, labels []string) Query {
+	if node == nil || len(labels) == 0 {
+		return q
+	}
+	operation := instruction + " " + escape(node.Id)
+	for _, label := range labels {
+		operation += ":" + escape(label)
+	}
+	return q.Custom(operation, Records{})
+}
+
+func (q query) delete(instruction string, items []interface{}) Query {
+	var ids []string
+	seen := map[string]bool{}
+	for _, item := range items {
+		var identifiers []string
+		switch value := item.(type) {
+		case string:
+			identifiers = []string{value}
+		case []string:
+			identifiers = value
+		case identifiable:
+			identifiers = value.identifiers()
+		default:
+			panic(fmt.Errorf("invalid %s item %v", instruction, item))
+		}
+		for _, id := range identifiers {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return q
+	}
+	operation := strings.Join(ids, ", ")
+	return q.Custom(instruction+" "+operation, Records{})
+}
+
+func (q query) foreach(alias string, list string, params Records, updates func(Query) Query) Query {
+	nested, nestedParams := updates(query{}).eval()
+	operation, params := combine(params, nested, nestedParams)
+	operation = "FOREACH (" + alias + " IN " + list + " | " + operation + ")"
+	return q.Custom(operation, params)
+}
+
+func (q query) projection(instruction string, props []Property) Query {
 	if len(props) == 0 {
 		return q
 	}
-	var operations []Operation
+	var projections []string
+	params := Records{}
 	for _, prop := range props {
-		operations = append(operations, prop.Get())
+		projection, p := prop.project()
+		projection, params = combine(params, projection, p)
+		projections = append(projections, projection)
+	}
+	operation := strings.Join(projections, ", ")
+	result := q.Custom(instruction+" "+operation, params).(query)
+	if strings.HasPrefix(instruction, "RETURN") {
+		result.returns = nil
+		for _, prop := range props {
+			column, _ := prop.Get().eval()
+			result.returns = append(result.returns, column)
+		}
 	}
-	operation, _ := chain(operations).eval()
-	return q.Custom("RETURN "+operation, Records{})
+	return result
 }
 
-func (q query) eval() (string, Records) {
-	operation := strings.Join(q.operations, " ")
-	return operation, q.params
+func projections(instruction string, items []interface{}) []Property {
+	var props []Property
+	for _, item := range items {
+		switch value := item.(type) {
+		case string:
+			props = append(props, property{name: value, alias: value})
+		case []string:
+			for _, id := range value {
+				props = append(props, property{name: id, alias: id})
+			}
+		case Property:
+			props = append(props, value)
+		default:
+			panic(fmt.Errorf("invalid %s item %v", instruction, item))
+		}
+	}
+	return props
 }
 
-func (q query) primary(instruction string, structure structure) Query {
-	value, params := structure.eval()
-	operation := instruction + " " + value
-	return q.Custom(operation, params)
+func union(operator string, queries []Query) (Query, error) {
+	if len(queries) == 0 {
+		return query{}, nil
+	}
+	var operations []string
+	var params Records
+	columns := queries[0].columns()
+	for i, q := range queries {
+		if len(q.columns()) == 0 {
+			return nil, fmt.Errorf("invalid UNION: query %d has no columns set by Return", i)
+		}
+		if !reflect.DeepEqual(q.columns(), columns) {
+			return nil, fmt.Errorf("invalid UNION: query %d returns %v instead of %v", i, q.columns(), columns)
+		}
+		operation, p := q.eval()
+		operation, params = combine(params, operation, p)
+		operations = append(operations, operation)
+	}
+	result := query{
+		operations: []string{strings.Join(operations, operator)},
+		params:     params,
+		returns:    columns,
+	}
+	return result, nil
+}
+
+func combine(params Records, operation string, others Records) (string, Records) {
+	merged := Records{}
+	for key, value := range params {
+		merged[key] = value
+	}
+	renames := map[string]string{}
+	for key, value := range others {
+		current, ok := merged[key]
+		if !ok || reflect.DeepEqual(current, value) {
+			merged[key] = value
+			continue
+		}
+		alias := key
+		for i := 2; ; i++ {
+			alias = fmt.Sprintf("%s_%d", key, i)
+			if _, taken := merged[alias]; !taken {
+				if _, taken = others[alias]; !taken {
+					break
+				}
+			}
+		}
+		merged[alias] = value
+		renames["$"+key] = "$" + alias
+	}
+	if len(renames) > 0 {
+		operation = parameter.ReplaceAllStringFunc(operation, func(name string) string {
+			if rename, ok := renames[name]; ok {
+				return rename
+			}
+			return name
+		})
+	}
+	return operation, merged
 }
 
 func chain(operations []Operation) Operation {
@@ -168,3 +534,10 @@ func chain(operations []Operation) Operation {
 	}
 	return first
 }
+
+func escape(name string) string {
+	if identifier.MatchString(name) {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
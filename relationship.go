@@ -1,7 +1,11 @@
 package neo4j
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/neo4j"
 )
 
 type Direction int
@@ -14,18 +18,97 @@ const (
 
 type Relationship struct {
 	Id, Type  string
+	Kind      LabelExpression
 	Props     Records
 	Direction Direction
+	Hops      *Hops
+}
+
+// Hops constructors panic on negative bounds or when the minimum exceeds the maximum.
+type Hops struct {
+	min, max       int
+	hasMin, hasMax bool
 }
 
 type Path struct {
-	Origin       *Node
+	Id           string
+	Origin       Pattern
 	Relationship *Relationship
-	Destination  Destination
+	Destination  Pattern
 }
 
-type Destination interface {
+type Shortest struct {
+	Id   string
+	Path *Path
+	All  bool
+}
+
+type Pattern interface {
 	extends() (string, Records)
+	identifiers() []string
+	reverse() Pattern
+}
+
+type Destination = Pattern
+
+func AnyHops() *Hops {
+	return &Hops{}
+}
+
+func ExactHops(hops int) *Hops {
+	return newHops(hops, hops, true, true)
+}
+
+func MinHops(min int) *Hops {
+	return newHops(min, 0, true, false)
+}
+
+func MaxHops(max int) *Hops {
+	return newHops(0, max, false, true)
+}
+
+func HopRange(min, max int) *Hops {
+	return newHops(min, max, true, true)
+}
+
+func ShortestPath(id string, path *Path) *Shortest {
+	return &Shortest{Id: id, Path: path}
+}
+
+func AllShortestPaths(id string, path *Path) *Shortest {
+	return &Shortest{Id: id, Path: path, All: true}
+}
+
+func DecodePath(value interface{}) (*Path, error) {
+	raw, ok := value.(neo4j.Path)
+	if !ok {
+		return nil, fmt.Errorf("invalid Path %v", value)
+	}
+	nodes, relationships := raw.Nodes(), raw.Relationships()
+	if len(nodes) == 0 || len(nodes) != len(relationships)+1 {
+		return nil, fmt.Errorf("invalid Path with %d nodes and %d relationships", len(nodes), len(relationships))
+	}
+	var destination Pattern = decodeNode(nodes[len(nodes)-1])
+	for i := len(relationships) - 1; i >= 0; i-- {
+		relationship := relationships[i]
+		direction := FromOriginToDestination
+		if relationship.StartId() != nodes[i].Id() {
+			direction = FromDestinationToOrigin
+		}
+		destination = &Path{
+			Origin: decodeNode(nodes[i]),
+			Relationship: &Relationship{
+				Type:      relationship.Type(),
+				Props:     relationship.Props(),
+				Direction: direction,
+			},
+			Destination: destination,
+		}
+	}
+	if path, ok := destination.(*Path); ok {
+		return path, nil
+	}
+	return &Path{Origin: decodeNode(nodes[0])}, nil
 }
 
 func (r *Relationship) Property(name string) Property {
@@ -43,6 +126,20 @@ func (r *Relationship) Properties(names ...string) []Property {
 	return props
 }
 
+func (r *Relationship) Variable() Property {
+	return property{
+		name:  r.Id,
+		alias: r.Id,
+	}
+}
+
+func (r *Relationship) identifiers() []string {
+	if r == nil || r.Id == "" {
+		return nil
+	}
+	return []string{r.Id}
+}
+
 func (r *Relationship) eval() (string, Records) {
 	if r == nil {
 		return "--", Records{}
@@ -51,21 +148,28 @@ func (r *Relationship) eval() (string, Records) {
 	if r.Type != "" {
 		kind = ":" + r.Type
 	}
+	if r.Kind != nil {
+		expression := r.Kind
+		if r.Type != "" {
+			expression = Label(r.Type).Or(r.Kind)
+		}
+		kind = ":" + expression.eval(0)
+	}
 	var props []string
 	params := Records{}
 	for _, key := range r.Props.Keys() {
-		alias := r.Id + "_" + key
-		prop := key + ": $" + alias
-		props = append(props, prop)
-		params[alias] = r.Props[key]
+		value, p := argument(r.Props[key], r.Id+"_"+key)
+		value, params = combine(params, value, p)
+		props = append(props, key+": "+value)
 	}
 	content := ""
 	if len(props) > 0 {
 		content = "{" + strings.Join(props, ", ") + "}"
 	}
+	length := r.Hops.eval()
 	arrow := ""
-	if r.Id != "" || kind != "" || content != "" {
-		arrow = "[" + r.Id + kind + content + "]"
+	if r.Id != "" || kind != "" || length != "" || content != "" {
+		arrow = "[" + r.Id + kind + length + content + "]"
 	}
 	switch r.Direction {
 	case FromOriginToDestination:
@@ -78,24 +182,181 @@ func (r *Relationship) eval() (string, Records) {
 	return arrow, params
 }
 
+func (r *Relationship) reverse() *Relationship {
+	if r == nil {
+		return nil
+	}
+	reversed := *r
+	switch r.Direction {
+	case FromOriginToDestination:
+		reversed.Direction = FromDestinationToOrigin
+	case FromDestinationToOrigin:
+		reversed.Direction = FromOriginToDestination
+	}
+	return &reversed
+}
+
+func (h *Hops) eval() string {
+	if h == nil {
+		return ""
+	}
+	if h.hasMin && h.hasMax && h.min == h.max {
+		return "*" + strconv.Itoa(h.min)
+	}
+	if !h.hasMin && !h.hasMax {
+		return "*"
+	}
+	length := "*"
+	if h.hasMin {
+		length += strconv.Itoa(h.min)
+	}
+	length += ".."
+	if h.hasMax {
+		length += strconv.Itoa(h.max)
+	}
+	return length
+}
+
+func (p *Path) Reverse() *Path {
+	if p == nil {
+		return nil
+	}
+	reversed := &Path{Id: p.Id}
+	if p.Destination == nil {
+		if p.Origin != nil {
+			reversed.Origin = p.Origin.reverse()
+		}
+		return reversed
+	}
+	reversed.Origin = p.Destination.reverse()
+	reversed.Relationship = p.Relationship.reverse()
+	reversed.Destination = &Node{}
+	if p.Origin != nil {
+		reversed.Destination = p.Origin.reverse()
+	}
+	return reversed
+}
+
+func (p *Path) Variable() Property {
+	return property{
+		name:  p.Id,
+		alias: p.Id,
+	}
+}
+
 func (p *Path) eval() (string, Records) {
+	operation, params := p.pattern()
+	if p != nil && p.Id != "" {
+		operation = p.Id + " = " + operation
+	}
+	return operation, params
+}
+
+func (p *Path) pattern() (string, Records) {
 	oOperation, oParams := "()", Records{}
 	if p == nil {
 		return oOperation, oParams
 	}
 	if p.Origin != nil {
-		oOperation, oParams = p.Origin.eval()
+		oOperation, oParams = p.Origin.extends()
 	}
 	if p.Destination == nil {
 		return oOperation, oParams
 	}
 	rOperation, rParams := p.Relationship.eval()
 	dOperation, dParams := p.Destination.extends()
-	operation := oOperation + rOperation + dOperation
-	params := oParams.Merge(rParams).Merge(dParams)
-	return operation, params
+	rOperation, params := combine(oParams, rOperation, rParams)
+	dOperation, params = combine(params, dOperation, dParams)
+	return oOperation + rOperation + dOperation, params
+}
+
+func (p *Path) identifiers() []string {
+	if p == nil {
+		return nil
+	}
+	if p.Id != "" {
+		return []string{p.Id}
+	}
+	var ids []string
+	if p.Origin != nil {
+		ids = p.Origin.identifiers()
+	}
+	if p.Destination == nil {
+		return ids
+	}
+	ids = append(ids, p.Relationship.identifiers()...)
+	return append(ids, p.Destination.identifiers()...)
 }
 
 func (p *Path) extends() (string, Records) {
-	return p.eval()
+	return p.pattern()
+}
+
+func (p *Path) reverse() Pattern {
+	return p.Reverse()
+}
+
+func (s *Shortest) Variable() Property {
+	return property{
+		name:  s.id(),
+		alias: s.id(),
+	}
+}
+
+func (s *Shortest) identifiers() []string {
+	if s.id() != "" {
+		return []string{s.id()}
+	}
+	return s.Path.identifiers()
+}
+
+func (s *Shortest) eval() (string, Records) {
+	function := "shortestPath"
+	if s.All {
+		function = "allShortestPaths"
+	}
+	pattern, params := s.Path.pattern()
+	operation := function + "(" + pattern + ")"
+	if s.id() != "" {
+		operation = s.id() + " = " + operation
+	}
+	return operation, params
+}
+
+func (s *Shortest) id() string {
+	if s.Id == "" && s.Path != nil {
+		return s.Path.Id
+	}
+	return s.Id
+}
+
+func decodeNode(node neo4j.Node) *Node {
+	return &Node{
+		Labels: node.Labels(),
+		Props:  node.Props(),
+	}
+}
+
+func newHops(min, max int, hasMin, hasMax bool) *Hops {
+	if min < 0 || max < 0 || (hasMin && hasMax && min > max) {
+		panic(fmt.Errorf("invalid Hops %d..%d", min, max))
+	}
+	return &Hops{min: min, max: max, hasMin: hasMin, hasMax: hasMax}
+}
+
+func variableLength(pattern interface{}) bool {
+	switch p := pattern.(type) {
+	case *Path:
+		if p == nil {
+			return false
+		}
+		if p.Relationship != nil && p.Relationship.Hops != nil && p.Destination != nil {
+			return true
+		}
+		return variableLength(p.Origin) || variableLength(p.Destination)
+	case *PathBuilder:
+		return variableLength(p.Path())
+	default:
+		return false
+	}
 }